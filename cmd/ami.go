@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	amiCommand = &cobra.Command{
+		Use:   "ami",
+		Short: "Create AMIs from instances and prune old ones",
+		Long:  "Create AMIs from instances before risky changes and clean up old AMIs (with their snapshots) by retention",
+	}
+
+	amiCreateCommand = &cobra.Command{
+		Use:   "create",
+		Short: "Create an AMI from an ec2 instance",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			states, _ := internal.ParseInstanceStates("all")
+			target := resolveSingleTargetWithStates(ctx, states, viper.GetString("ami-target"), viper.GetString("ami-group"), viper.GetString("ami-name-tag"), "AMI 를 만들 인스턴스를 선택하세요:")
+
+			tags, err := internal.ParseTags(viper.GetStringSlice("ami-tag"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			options := internal.AmiOptions{
+				Name:        strings.TrimSpace(viper.GetString("ami-name")),
+				Description: viper.GetString("ami-description"),
+				NoReboot:    viper.GetBool("ami-no-reboot"),
+				Tags:        tags,
+			}
+			if options.Name == "" {
+				options.Name = internal.DefaultAmiName(target)
+			}
+
+			// no-reboot 가 아니면 실행 중인 인스턴스가 재부팅됨
+			if !options.NoReboot && target.State == "running" && !viper.GetBool("ami-yes") {
+				var proceed bool
+				prompt := &survey.Confirm{Message: fmt.Sprintf("%s (%s) 이(가) 재부팅됩니다. 계속하시겠습니까? (--no-reboot 로 재부팅 없이 생성)", target.Name, target.Id), Default: false}
+				if err := survey.AskOne(prompt, &proceed); err != nil {
+					internal.RealPanic(err)
+				}
+				if !proceed {
+					internal.LogWarning("작업이 취소되었습니다.")
+					return
+				}
+			}
+
+			imageId, err := internal.CreateImage(ctx, *awsConfig, target, options)
+			recordAudit(ctx, "ami create", []string{target.Id}, map[string]string{
+				"name":     options.Name,
+				"image":    imageId,
+				"noReboot": strconv.FormatBool(options.NoReboot),
+			}, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("AMI 생성을 시작했습니다: %s (%s) from %s (%s)", options.Name, imageId, target.Name, target.Id)
+
+			if !viper.GetBool("ami-wait") {
+				return
+			}
+			internal.LogInfo("AMI 가 available 상태가 될 때까지 대기합니다...")
+			start := time.Now()
+			if err := internal.WaitImageAvailable(ctx, *awsConfig, imageId, viper.GetDuration("ami-wait-timeout")); err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("AMI 사용 가능: %s (%s)", imageId, time.Since(start).Round(time.Second))
+		},
+	}
+
+	amiPruneCommand = &cobra.Command{
+		Use:   "prune",
+		Short: "Deregister old AMIs and delete their snapshots, keeping the newest N per group",
+		Long:  "Group own AMIs by --name pattern (each pattern is a group) or by the value of --by-tag, keep the newest --keep per group and deregister the rest together with their snapshots. AMIs used by existing instances are never deleted",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			patterns := viper.GetStringSlice("ami-prune-name")
+			groupTag := strings.TrimSpace(viper.GetString("ami-prune-by-tag"))
+			if len(patterns) == 0 && groupTag == "" {
+				internal.RealPanic(fmt.Errorf("specify --name pattern(s) or --by-tag"))
+			}
+			keep := viper.GetInt("ami-prune-keep")
+			if keep < 1 {
+				internal.RealPanic(fmt.Errorf("--keep must be at least 1"))
+			}
+
+			filters, err := internal.ParseTagFilters(viper.GetStringSlice("ami-prune-tag"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			if groupTag != "" {
+				filters = append(filters, internal.TagFilter{Key: groupTag})
+			}
+
+			images, err := internal.FindOwnedImages(ctx, *awsConfig, patterns, filters)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			inUse, err := internal.FindImagesInUse(ctx, *awsConfig)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			plan := internal.PlanImagePrune(images, patterns, groupTag, keep, inUse)
+			if len(plan) == 0 {
+				internal.LogWarning("조건에 맞는 AMI 가 없습니다.")
+				return
+			}
+			internal.PrintAmiPrunePlan(plan)
+
+			var deletions []internal.AmiPrunePlan
+			for _, p := range plan {
+				if p.Action == internal.AmiActionDelete {
+					deletions = append(deletions, p)
+				}
+			}
+			if len(deletions) == 0 {
+				internal.LogInfo("삭제할 AMI 가 없습니다.")
+				return
+			}
+			if viper.GetBool("ami-prune-dry-run") {
+				internal.LogInfo("dry-run: %d개 AMI 가 삭제 대상입니다.", len(deletions))
+				return
+			}
+
+			if !viper.GetBool("ami-yes") {
+				var proceed bool
+				prompt := &survey.Confirm{Message: fmt.Sprintf("%d개 AMI 와 스냅샷을 삭제하시겠습니까?", len(deletions)), Default: false}
+				if err := survey.AskOne(prompt, &proceed); err != nil {
+					internal.RealPanic(err)
+				}
+				if !proceed {
+					internal.LogWarning("작업이 취소되었습니다.")
+					return
+				}
+			}
+
+			var failed int
+			var firstErr error
+			ids := make([]string, 0, len(deletions))
+			for _, p := range deletions {
+				ids = append(ids, p.Image.Id)
+				if err := internal.DeregisterImage(ctx, *awsConfig, p.Image); err != nil {
+					failed++
+					if firstErr == nil {
+						firstErr = err
+					}
+					internal.LogError("%s (%s): %v", p.Image.Name, p.Image.Id, err)
+					continue
+				}
+				internal.LogSuccess("삭제: %s (%s), snapshots: %s", p.Image.Name, p.Image.Id, strings.Join(p.Image.Snapshots, ","))
+			}
+			recordAudit(ctx, "ami prune", ids, map[string]string{
+				"keep":  strconv.Itoa(keep),
+				"name":  strings.Join(patterns, ","),
+				"byTag": groupTag,
+			}, firstErr)
+			internal.LogBatchSummary("ami", "prune", len(deletions)-failed, failed)
+		},
+	}
+)
+
+func init() {
+	amiCommand.PersistentFlags().BoolP("yes", "y", false, "skip confirmation")
+	viper.BindPFlag("ami-yes", amiCommand.PersistentFlags().Lookup("yes"))
+
+	amiCreateCommand.Flags().StringP("target", "t", "", "ec2 instanceId")
+	amiCreateCommand.Flags().StringP("group", "g", "", "ec2 instance group (value of the group tag, glob allowed)")
+	amiCreateCommand.Flags().String("instance-name", "", "ec2 instance Name tag (glob allowed)")
+	amiCreateCommand.Flags().String("name", "", "AMI name (default <instance name>-<yyyymmdd-hhmmss>)")
+	amiCreateCommand.Flags().String("description", "", "AMI description")
+	amiCreateCommand.Flags().Bool("no-reboot", false, "do not reboot the instance (filesystem consistency is not guaranteed)")
+	amiCreateCommand.Flags().StringArray("tag", nil, "tag the AMI and its snapshots with KEY=VALUE (repeatable)")
+	amiCreateCommand.Flags().Bool("wait", true, "wait until the AMI is available")
+	amiCreateCommand.Flags().Duration("wait-timeout", 60*time.Minute, "maximum time to wait for the AMI")
+	viper.BindPFlag("ami-target", amiCreateCommand.Flags().Lookup("target"))
+	viper.BindPFlag("ami-group", amiCreateCommand.Flags().Lookup("group"))
+	viper.BindPFlag("ami-name-tag", amiCreateCommand.Flags().Lookup("instance-name"))
+	viper.BindPFlag("ami-name", amiCreateCommand.Flags().Lookup("name"))
+	viper.BindPFlag("ami-description", amiCreateCommand.Flags().Lookup("description"))
+	viper.BindPFlag("ami-no-reboot", amiCreateCommand.Flags().Lookup("no-reboot"))
+	viper.BindPFlag("ami-tag", amiCreateCommand.Flags().Lookup("tag"))
+	viper.BindPFlag("ami-wait", amiCreateCommand.Flags().Lookup("wait"))
+	viper.BindPFlag("ami-wait-timeout", amiCreateCommand.Flags().Lookup("wait-timeout"))
+
+	amiPruneCommand.Flags().StringArray("name", nil, "AMI name pattern, each pattern is one retention group (repeatable, glob)")
+	amiPruneCommand.Flags().String("by-tag", "", "group AMIs by the value of this tag instead of name patterns")
+	amiPruneCommand.Flags().StringArray("tag", nil, "only consider AMIs with tag key=value (repeatable)")
+	amiPruneCommand.Flags().Int("keep", 5, "number of newest AMIs to keep per group")
+	amiPruneCommand.Flags().Bool("dry-run", false, "only list what would be deleted")
+	viper.BindPFlag("ami-prune-name", amiPruneCommand.Flags().Lookup("name"))
+	viper.BindPFlag("ami-prune-by-tag", amiPruneCommand.Flags().Lookup("by-tag"))
+	viper.BindPFlag("ami-prune-tag", amiPruneCommand.Flags().Lookup("tag"))
+	viper.BindPFlag("ami-prune-keep", amiPruneCommand.Flags().Lookup("keep"))
+	viper.BindPFlag("ami-prune-dry-run", amiPruneCommand.Flags().Lookup("dry-run"))
+
+	amiCommand.AddCommand(amiCreateCommand, amiPruneCommand)
+	rootCmd.AddCommand(amiCommand)
+}
@@ -0,0 +1,491 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	asgCommand = &cobra.Command{
+		Use:   "asg",
+		Short: "Manage auto scaling groups",
+		Long:  "List auto scaling groups, change desired capacity, suspend/resume processes, run instance refreshes, move instances in and out of standby and connect to group members. The group name argument is optional; without it a picker is shown",
+	}
+
+	asgListCommand = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List auto scaling groups with desired/min/max and in-service counts",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			format := strings.ToLower(viper.GetString("asg-list-output"))
+			if err := internal.ValidateOutputFormat(format); err != nil {
+				internal.RealPanic(err)
+			}
+
+			groups, err := internal.FindAutoScalingGroups(ctx, *awsConfig)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			if len(groups) == 0 && format == internal.OutputTable {
+				internal.LogWarning("Auto Scaling 그룹이 없습니다.")
+				return
+			}
+
+			headers, rows := internal.AutoScalingGroupTable(groups)
+			if err := internal.PrintOutput(format, headers, rows, groups); err != nil {
+				internal.RealPanic(err)
+			}
+		},
+	}
+
+	asgInstancesCommand = &cobra.Command{
+		Use:   "instances [group]",
+		Short: "Show the instances of an auto scaling group",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			group := resolveAsg(ctx, args)
+			instances, err := internal.FindInstanceWithFilter(ctx, *awsConfig, internal.AsgInstanceFilter(group.Name, internal.InstanceStates[:len(internal.InstanceStates)-1]))
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			internal.LogInfo("%s: desired %d, min %d, max %d, in service %d", group.Name, group.Desired, group.Min, group.Max, group.InService)
+			if len(group.Suspended) > 0 {
+				internal.LogWarning("중지된 프로세스: %s", strings.Join(group.Suspended, ", "))
+			}
+			internal.PrintAutoScalingMembers(group, instances)
+		},
+	}
+
+	asgScaleCommand = &cobra.Command{
+		Use:   "scale [group]",
+		Short: "Set the desired capacity of an auto scaling group",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			group := resolveAsg(ctx, args)
+			desired := viper.GetInt("asg-scale-desired")
+			if desired < 0 {
+				var answer string
+				prompt := &survey.Input{Message: fmt.Sprintf("desired capacity (현재 %d, min %d, max %d):", group.Desired, group.Min, group.Max)}
+				if err := survey.AskOne(prompt, &answer, survey.WithValidator(survey.Required)); err != nil {
+					internal.RealPanic(err)
+				}
+				value, err := strconv.Atoi(strings.TrimSpace(answer))
+				if err != nil {
+					internal.RealPanic(fmt.Errorf("invalid desired capacity %q", answer))
+				}
+				desired = value
+			}
+
+			if !confirmAsg(fmt.Sprintf("%s 의 desired capacity 를 %d → %d 로 변경하시겠습니까?", group.Name, group.Desired, desired)) {
+				internal.LogWarning("작업이 취소되었습니다.")
+				return
+			}
+
+			err := internal.SetDesiredCapacity(ctx, *awsConfig, group, int32(desired), viper.GetBool("asg-scale-honor-cooldown"))
+			recordAudit(ctx, "asg scale", []string{group.Name}, map[string]string{
+				"from": strconv.Itoa(int(group.Desired)),
+				"to":   strconv.Itoa(desired),
+			}, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("%s: desired capacity %d → %d", group.Name, group.Desired, desired)
+		},
+	}
+
+	asgSuspendCommand = newAsgProcessCommand(true)
+	asgResumeCommand  = newAsgProcessCommand(false)
+
+	asgRefreshCommand = &cobra.Command{
+		Use:   "refresh",
+		Short: "Start, monitor and cancel instance refreshes",
+	}
+
+	asgRefreshStartCommand = &cobra.Command{
+		Use:   "start [group]",
+		Short: "Start an instance refresh and follow its progress",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			group := resolveAsg(ctx, args)
+			options := internal.InstanceRefreshOptions{
+				MinHealthyPercentage: int32(viper.GetInt("asg-refresh-min-healthy")),
+				InstanceWarmup:       int32(viper.GetDuration("asg-refresh-warmup").Seconds()),
+				SkipMatching:         viper.GetBool("asg-refresh-skip-matching"),
+			}
+			if !confirmAsg(fmt.Sprintf("%s 의 인스턴스 %d개를 교체하시겠습니까? (min healthy %d%%)", group.Name, len(group.Instances), options.MinHealthyPercentage)) {
+				internal.LogWarning("작업이 취소되었습니다.")
+				return
+			}
+
+			id, err := internal.StartInstanceRefresh(ctx, *awsConfig, group.Name, options)
+			recordAudit(ctx, "asg refresh start", []string{group.Name}, map[string]string{
+				"refresh":      id,
+				"minHealthy":   strconv.Itoa(int(options.MinHealthyPercentage)),
+				"skipMatching": strconv.FormatBool(options.SkipMatching),
+			}, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("%s: 인스턴스 새로 고침을 시작했습니다 (%s)", group.Name, id)
+
+			if viper.GetBool("asg-refresh-watch") {
+				if err := watchInstanceRefresh(ctx, group.Name, id); err != nil {
+					internal.RealPanic(err)
+				}
+			}
+		},
+	}
+
+	asgRefreshStatusCommand = &cobra.Command{
+		Use:   "status [group]",
+		Short: "Show the latest instance refresh",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			group := resolveAsg(ctx, args)
+			if viper.GetBool("asg-refresh-watch") {
+				if err := watchInstanceRefresh(ctx, group.Name, ""); err != nil {
+					internal.RealPanic(err)
+				}
+				return
+			}
+
+			refresh, err := internal.DescribeInstanceRefresh(ctx, *awsConfig, group.Name, "")
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogInfo("%s: %s", group.Name, refresh.Summary())
+		},
+	}
+
+	asgRefreshCancelCommand = &cobra.Command{
+		Use:   "cancel [group]",
+		Short: "Cancel the running instance refresh",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			group := resolveAsg(ctx, args)
+			if !confirmAsg(fmt.Sprintf("%s 의 인스턴스 새로 고침을 취소하시겠습니까?", group.Name)) {
+				internal.LogWarning("작업이 취소되었습니다.")
+				return
+			}
+
+			id, err := internal.CancelInstanceRefresh(ctx, *awsConfig, group.Name)
+			recordAudit(ctx, "asg refresh cancel", []string{group.Name}, map[string]string{"refresh": id}, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("%s: 인스턴스 새로 고침 취소 요청 (%s)", group.Name, id)
+		},
+	}
+
+	asgStandbyCommand = &cobra.Command{
+		Use:   "standby",
+		Short: "Move instances into or out of standby",
+	}
+
+	asgStandbyEnterCommand = &cobra.Command{
+		Use:   "enter [group]",
+		Short: "Put in-service instances into standby (removed from load balancing, kept running)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runAsgStandby(args, true)
+		},
+	}
+
+	asgStandbyExitCommand = &cobra.Command{
+		Use:   "exit [group]",
+		Short: "Return standby instances to service",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runAsgStandby(args, false)
+		},
+	}
+
+	asgSshCommand = &cobra.Command{
+		Use:   "ssh [group]",
+		Short: "Open an SSH shell on an instance of an auto scaling group through the bastion",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			target := resolveAsgTarget(ctx, args, "접속할 인스턴스를 선택하세요:")
+			runSSHSession(ctx, target, internal.ShellOptions{User: viper.GetString("asg-ssh-user")})
+		},
+	}
+
+	asgSsmCommand = &cobra.Command{
+		Use:   "ssm [group]",
+		Short: "Open an SSM session to an instance of an auto scaling group",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			if ok, missing := internal.CheckSSMClientInstalled(); !ok {
+				internal.LogWarning("SSM 클라이언트가 설치되어 있지 않습니다.")
+				internal.PrintSSMInstallGuide(missing)
+				return
+			}
+
+			target := resolveAsgTarget(ctx, args, "SSM으로 접속할 인스턴스를 선택하세요:")
+			internal.LogInfo("SSM 세션을 시작합니다: %s (%s)", target.Name, target.Id)
+			if err := internal.StartSSMSession(ctx, target.Id, awsConfig.Region); err != nil {
+				internal.RealPanic(fmt.Errorf("SSM 세션 연결 실패: %w", err))
+			}
+		},
+	}
+)
+
+// suspend / resume 서브커맨드 (--process 가 없으면 전체 프로세스)
+func newAsgProcessCommand(suspend bool) *cobra.Command {
+	use, short, operation := "resume", "Resume suspended scaling processes", "resume"
+	if suspend {
+		use, short, operation = "suspend", "Suspend scaling processes (e.g. during maintenance)", "suspend"
+	}
+
+	return &cobra.Command{
+		Use:   use + " [group]",
+		Short: short,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			group := resolveAsg(ctx, args)
+			processes, err := internal.ParseAsgProcesses(viper.GetStringSlice("asg-" + operation + "-process"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			label := strings.Join(processes, ", ")
+			if label == "" {
+				label = "all processes"
+			}
+			if !confirmAsg(fmt.Sprintf("%s: %s %s?", group.Name, operation, label)) {
+				internal.LogWarning("작업이 취소되었습니다.")
+				return
+			}
+
+			if suspend {
+				err = internal.SuspendProcesses(ctx, *awsConfig, group.Name, processes)
+			} else {
+				err = internal.ResumeProcesses(ctx, *awsConfig, group.Name, processes)
+			}
+			recordAudit(ctx, "asg "+operation, []string{group.Name}, map[string]string{"processes": label}, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("%s: %s %s", group.Name, operation, label)
+		},
+	}
+}
+
+// 인자로 받은 그룹 이름, 없으면 목록에서 선택
+func resolveAsg(ctx context.Context, args []string) *internal.AutoScalingGroup {
+	awsConfig := mustAwsConfig()
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		group, err := internal.FindAutoScalingGroup(ctx, *awsConfig, strings.TrimSpace(args[0]))
+		if err != nil {
+			internal.RealPanic(internal.WrapError(err))
+		}
+		return group
+	}
+
+	groups, err := internal.FindAutoScalingGroups(ctx, *awsConfig)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	group, err := internal.AskAutoScalingGroup(groups, "Auto Scaling 그룹을 선택하세요:")
+	if err != nil {
+		internal.RealPanic(err)
+	}
+	return group
+}
+
+// 그룹의 실행 중인 인스턴스 중 하나 선택
+func resolveAsgTarget(ctx context.Context, args []string, message string) *internal.Target {
+	awsConfig := mustAwsConfig()
+	group := resolveAsg(ctx, args)
+
+	table, err := internal.FindInstanceWithFilter(ctx, *awsConfig, internal.AsgInstanceFilter(group.Name, []string{"running"}))
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	targets := make([]*internal.Target, 0, len(table))
+	for _, target := range table {
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		internal.RealPanic(fmt.Errorf("no running instance in auto scaling group %s", group.Name))
+	}
+
+	target, err := internal.AskTargetFrom(targets, message)
+	if err != nil {
+		internal.RealPanic(err)
+	}
+	return target
+}
+
+func runAsgStandby(args []string, enter bool) {
+	ctx := context.Background()
+	awsConfig := mustAwsConfig()
+	group := resolveAsg(ctx, args)
+
+	from, operation := "InService", "asg standby exit"
+	if enter {
+		operation = "asg standby enter"
+	} else {
+		from = "Standby"
+	}
+
+	ids := strings.FieldsFunc(viper.GetString("asg-standby-instance"), func(r rune) bool { return r == ',' || r == ' ' })
+	if len(ids) == 0 {
+		var candidates []internal.AutoScalingMember
+		for _, member := range group.Instances {
+			if member.LifecycleState == from {
+				candidates = append(candidates, member)
+			}
+		}
+		if len(candidates) == 0 {
+			internal.LogWarning("%s 상태의 인스턴스가 없습니다.", from)
+			return
+		}
+		var err error
+		ids, err = internal.AskAutoScalingMembers(candidates, "인스턴스를 선택하세요:")
+		if err != nil {
+			internal.RealPanic(err)
+		}
+	}
+
+	decrement := viper.GetBool("asg-standby-decrement")
+	message := fmt.Sprintf("%s 의 인스턴스 %d개를 standby 에서 복귀시키겠습니까?", group.Name, len(ids))
+	if enter {
+		message = fmt.Sprintf("%s 의 인스턴스 %d개를 standby 로 전환하시겠습니까?", group.Name, len(ids))
+		if !decrement {
+			message += " (desired capacity 유지: 대체 인스턴스가 시작됨)"
+		}
+	}
+	if !confirmAsg(message) {
+		internal.LogWarning("작업이 취소되었습니다.")
+		return
+	}
+
+	var err error
+	if enter {
+		err = internal.EnterStandby(ctx, *awsConfig, group.Name, ids, decrement)
+	} else {
+		err = internal.ExitStandby(ctx, *awsConfig, group.Name, ids)
+	}
+	params := map[string]string{"group": group.Name}
+	if enter {
+		params["decrement"] = strconv.FormatBool(decrement)
+	}
+	recordAudit(ctx, operation, ids, params, err)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	internal.LogSuccess("%s: %s (%s)", group.Name, strings.TrimPrefix(operation, "asg "), strings.Join(ids, ", "))
+}
+
+// Ctrl+C 로 모니터링만 중단 (새로 고침은 계속 진행)
+// 성공(Successful) 이 아닌 상태로 끝나면 에러 (Ctrl+C 로 모니터링만 중단한 경우는 nil)
+func watchInstanceRefresh(ctx context.Context, name, id string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	refresh, err := internal.WatchInstanceRefresh(ctx, *mustAwsConfig(), name, id, viper.GetDuration("asg-refresh-interval"))
+	if ctx.Err() != nil {
+		internal.LogInfo("모니터링을 중단했습니다. 인스턴스 새로 고침은 계속 진행됩니다.")
+		return nil
+	}
+	if err != nil {
+		return internal.WrapError(err)
+	}
+	if !refresh.Succeeded() {
+		return fmt.Errorf("%s: instance refresh %s", name, refresh.Status)
+	}
+	internal.LogSuccess("%s: 인스턴스 새로 고침 완료", name)
+	return nil
+}
+
+func confirmAsg(message string) bool {
+	if viper.GetBool("asg-yes") {
+		return true
+	}
+
+	var proceed bool
+	if err := survey.AskOne(&survey.Confirm{Message: message, Default: false}, &proceed); err != nil {
+		internal.RealPanic(err)
+	}
+	return proceed
+}
+
+func init() {
+	asgCommand.PersistentFlags().BoolP("yes", "y", false, "skip confirmation")
+	viper.BindPFlag("asg-yes", asgCommand.PersistentFlags().Lookup("yes"))
+
+	asgListCommand.Flags().StringP("output", "o", internal.OutputTable, "output format (table, json, csv)")
+	viper.BindPFlag("asg-list-output", asgListCommand.Flags().Lookup("output"))
+
+	asgScaleCommand.Flags().Int("desired", -1, "new desired capacity (asked when omitted)")
+	asgScaleCommand.Flags().Bool("honor-cooldown", false, "wait for the cooldown period to complete before scaling")
+	viper.BindPFlag("asg-scale-desired", asgScaleCommand.Flags().Lookup("desired"))
+	viper.BindPFlag("asg-scale-honor-cooldown", asgScaleCommand.Flags().Lookup("honor-cooldown"))
+
+	for _, c := range []*cobra.Command{asgSuspendCommand, asgResumeCommand} {
+		c.Flags().StringArray("process", nil, fmt.Sprintf("process to suspend/resume, comma separated or repeatable (default all: %s)", strings.Join(internal.AsgProcesses, ", ")))
+	}
+	viper.BindPFlag("asg-suspend-process", asgSuspendCommand.Flags().Lookup("process"))
+	viper.BindPFlag("asg-resume-process", asgResumeCommand.Flags().Lookup("process"))
+
+	asgRefreshCommand.PersistentFlags().Bool("watch", true, "follow the refresh until it finishes (Ctrl+C stops watching only)")
+	asgRefreshCommand.PersistentFlags().Duration("interval", 15*time.Second, "polling interval for --watch")
+	asgRefreshStartCommand.Flags().Int("min-healthy", 90, "minimum healthy percentage during the refresh")
+	asgRefreshStartCommand.Flags().Duration("warmup", 0, "instance warmup (default: the group's health check grace period)")
+	asgRefreshStartCommand.Flags().Bool("skip-matching", false, "skip instances that already match the launch template")
+	viper.BindPFlag("asg-refresh-watch", asgRefreshCommand.PersistentFlags().Lookup("watch"))
+	viper.BindPFlag("asg-refresh-interval", asgRefreshCommand.PersistentFlags().Lookup("interval"))
+	viper.BindPFlag("asg-refresh-min-healthy", asgRefreshStartCommand.Flags().Lookup("min-healthy"))
+	viper.BindPFlag("asg-refresh-warmup", asgRefreshStartCommand.Flags().Lookup("warmup"))
+	viper.BindPFlag("asg-refresh-skip-matching", asgRefreshStartCommand.Flags().Lookup("skip-matching"))
+
+	asgStandbyCommand.PersistentFlags().String("instance", "", "instance ids, comma separated (picker when omitted)")
+	asgStandbyEnterCommand.Flags().Bool("decrement", true, "decrement the desired capacity so no replacement is launched")
+	viper.BindPFlag("asg-standby-instance", asgStandbyCommand.PersistentFlags().Lookup("instance"))
+	viper.BindPFlag("asg-standby-decrement", asgStandbyEnterCommand.Flags().Lookup("decrement"))
+
+	asgSshCommand.Flags().StringP("user", "u", "", "remote user (default ec2-user)")
+	viper.BindPFlag("asg-ssh-user", asgSshCommand.Flags().Lookup("user"))
+
+	asgRefreshCommand.AddCommand(asgRefreshStartCommand, asgRefreshStatusCommand, asgRefreshCancelCommand)
+	asgStandbyCommand.AddCommand(asgStandbyEnterCommand, asgStandbyExitCommand)
+	asgCommand.AddCommand(asgListCommand, asgInstancesCommand, asgScaleCommand, asgSuspendCommand, asgResumeCommand,
+		asgRefreshCommand, asgStandbyCommand, asgSshCommand, asgSsmCommand)
+	rootCmd.AddCommand(asgCommand)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	auditCommand = &cobra.Command{
+		Use:   "audit",
+		Short: "Show the audit log of mutating actions",
+		Long:  "Show the audit log of mutating actions (volume expansion, cloudfront invalidation, kubectl apply/delete, ...)",
+		Run: func(cmd *cobra.Command, args []string) {
+			var since time.Time
+			argSince := strings.TrimSpace(viper.GetString("audit-since"))
+			if argSince != "" {
+				parsed, err := parseSince(argSince)
+				if err != nil {
+					internal.RealPanic(internal.WrapError(err))
+				}
+				since = parsed
+			}
+
+			argAction := strings.TrimSpace(viper.GetString("audit-action"))
+			entries, err := internal.ReadAuditEntries(since, argAction)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			if len(entries) == 0 {
+				internal.LogWarning("감사 로그가 없습니다: %s", internal.AuditLogPath())
+				return
+			}
+
+			for _, entry := range entries {
+				internal.PrintAuditEntry("audit", entry)
+			}
+		},
+	}
+)
+
+// 변경 작업 감사 로그 기록 (기록 실패는 작업 결과에 영향을 주지 않음)
+func recordAudit(ctx context.Context, command string, targets []string, params map[string]string, actionErr error) {
+	entry := internal.AuditEntry{
+		Timestamp:  time.Now().UTC(),
+		Profile:    currentProfile(),
+		Command:    command,
+		Targets:    targets,
+		Parameters: params,
+		Outcome:    internal.AuditOutcomeSuccess,
+	}
+	if actionErr != nil {
+		entry.Outcome = internal.AuditOutcomeFailure
+		entry.Error = actionErr.Error()
+	}
+
+	if awsConfig := GetGlobalAwsConfig(); awsConfig != nil {
+		entry.Region = awsConfig.Region
+		identity, err := internal.GetCallerIdentityArn(ctx, *awsConfig)
+		if err != nil {
+			identity = "unknown"
+		}
+		entry.Identity = identity
+	}
+
+	if err := internal.WriteAuditEntry(entry, viper.GetString("audit-sink")); err != nil {
+		internal.LogWarning("감사 로그 기록 실패: %v", err)
+	}
+}
+
+// 현재 사용 중인 프로파일 (aws-vault 사용 시 AWS_VAULT)
+func currentProfile() string {
+	if credential != nil && credential.awsProfile != "" {
+		return credential.awsProfile
+	}
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		return profile
+	}
+	if profile := os.Getenv("AWS_VAULT"); profile != "" {
+		return profile
+	}
+	return defaultProfile
+}
+
+// --since 값 파싱 (24h, 7d 같은 기간 또는 2006-01-02, RFC3339 시각)
+func parseSince(value string) (time.Time, error) {
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+			return time.Now().AddDate(0, 0, -days), nil
+		}
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value: %s", value)
+}
+
+func init() {
+	auditCommand.Flags().String("since", "", "show entries since duration (24h, 7d) or date (2006-01-02, RFC3339)")
+	auditCommand.Flags().String("action", "", "filter entries by command (e.g. volume, cloudfront, eks)")
+	viper.BindPFlag("audit-since", auditCommand.Flags().Lookup("since"))
+	viper.BindPFlag("audit-action", auditCommand.Flags().Lookup("action"))
+
+	rootCmd.AddCommand(auditCommand)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	certCommand = &cobra.Command{
+		Use:   "cert",
+		Short: "Issue a short-lived SSH user certificate signed by the team CA",
+		Long:  "Sign ~/.ssh/mcl_ed25519.pub with the team CA key (file or KMS) for the chosen duration. Principals come from the SSH-Principals tag of the selected instances. ssh, exec, cp, tunnel and volume authenticate with the certificate while it is valid",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			caKey, caKms := strings.TrimSpace(viper.GetString("cert-ca-key")), strings.TrimSpace(viper.GetString("cert-ca-kms"))
+			if (caKey == "") == (caKms == "") {
+				internal.RealPanic(fmt.Errorf("specify exactly one of --ca-key or --ca-kms (env: MCL_CA_KEY, MCL_CA_KMS)"))
+			}
+
+			principals := viper.GetStringSlice("cert-principal")
+			var targetIds []string
+			if len(principals) == 0 {
+				targets := resolveTargetSet(ctx, viper.GetString("cert-target"), viper.GetString("cert-group"), viper.GetString("cert-name"), "인증서로 접속할 인스턴스를 선택하세요:")
+				for _, target := range targets {
+					targetIds = append(targetIds, target.Id)
+				}
+
+				user := viper.GetString("cert-user")
+				if user == "" {
+					user = "ec2-user"
+				}
+				principals = internal.PrincipalsForTargets(targets, viper.GetString("cert-principal-tag"), user)
+			}
+
+			duration := certDuration()
+
+			identity := viper.GetString("cert-identity")
+			if identity == "" {
+				identity = internal.CertIdentityPath()
+			}
+			publicKey, created, err := internal.LoadOrCreateIdentity(identity)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			if created {
+				internal.LogInfo("사용자 키를 생성했습니다: %s", identity)
+			}
+
+			var ca ssh.Signer
+			if caKms != "" {
+				ca, err = internal.NewKMSSigner(ctx, *awsConfig, caKms)
+			} else {
+				ca, err = internal.LoadCASigner(caKey)
+			}
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			keyId, err := internal.GetCallerIdentityArn(ctx, *awsConfig)
+			if err != nil {
+				keyId = os.Getenv("USER")
+			}
+
+			cert, err := internal.SignUserCertificate(ca, publicKey, internal.CertOptions{
+				KeyId:      keyId,
+				Principals: principals,
+				Duration:   duration,
+			})
+			if err == nil {
+				err = internal.WriteCertificate(internal.CertificatePath(identity), cert)
+			}
+			recordAudit(ctx, "cert", targetIds, map[string]string{
+				"principals": strings.Join(principals, ","),
+				"duration":   duration.String(),
+				"ca":         ssh.FingerprintSHA256(ca.PublicKey()),
+			}, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			internal.PrintCertificate("cert", internal.CertificatePath(identity), cert)
+			if identity != internal.CertIdentityPath() {
+				internal.LogWarning("mcl 의 SSH 기능은 %s 인증서만 자동으로 사용합니다", internal.CertificatePath(internal.CertIdentityPath()))
+			}
+		},
+	}
+)
+
+// --duration 이 없으면 선택
+func certDuration() time.Duration {
+	value := strings.TrimSpace(viper.GetString("cert-duration"))
+	if value == "" {
+		selected, err := internal.AskTime()
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		value = selected.Name
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		internal.RealPanic(fmt.Errorf("invalid --duration value: %s", value))
+	}
+	return duration
+}
+
+func init() {
+	certCommand.Flags().String("ca-key", "", "CA private key file (env: MCL_CA_KEY)")
+	certCommand.Flags().String("ca-kms", "", "KMS asymmetric SIGN_VERIFY key id, ARN or alias/<name> used as CA (env: MCL_CA_KMS)")
+	certCommand.Flags().String("duration", "", "certificate lifetime, e.g. 30m, 12h (default: choose)")
+	certCommand.Flags().String("identity", "", "private key to certify (default ~/.ssh/mcl_ed25519, created if missing)")
+	certCommand.Flags().StringP("target", "t", "", "ec2 instanceId (comma separated)")
+	certCommand.Flags().StringP("group", "g", "", "ec2 instance group (value of the group tag, glob allowed)")
+	certCommand.Flags().StringP("name", "n", "", "ec2 instance Name tag (glob allowed)")
+	certCommand.Flags().String("principal-tag", internal.DefaultPrincipalTag, "instance tag listing principals (comma separated)")
+	certCommand.Flags().StringArray("principal", nil, "principal to include instead of reading instance tags (repeatable)")
+	certCommand.Flags().StringP("user", "u", "", "principal used when the instances have no principal tag (default ec2-user)")
+	viper.BindPFlag("cert-ca-key", certCommand.Flags().Lookup("ca-key"))
+	viper.BindEnv("cert-ca-key", "MCL_CA_KEY")
+	viper.BindPFlag("cert-ca-kms", certCommand.Flags().Lookup("ca-kms"))
+	viper.BindEnv("cert-ca-kms", "MCL_CA_KMS")
+	viper.BindPFlag("cert-duration", certCommand.Flags().Lookup("duration"))
+	viper.BindPFlag("cert-identity", certCommand.Flags().Lookup("identity"))
+	viper.BindPFlag("cert-target", certCommand.Flags().Lookup("target"))
+	viper.BindPFlag("cert-group", certCommand.Flags().Lookup("group"))
+	viper.BindPFlag("cert-name", certCommand.Flags().Lookup("name"))
+	viper.BindPFlag("cert-principal-tag", certCommand.Flags().Lookup("principal-tag"))
+	viper.BindPFlag("cert-principal", certCommand.Flags().Lookup("principal"))
+	viper.BindPFlag("cert-user", certCommand.Flags().Lookup("user"))
+
+	rootCmd.AddCommand(certCommand)
+}
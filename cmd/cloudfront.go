@@ -53,6 +53,7 @@ var (
 			invalidation := viper.GetBool("cloudfront-invalidation")
 			if invalidation {
 				err = internal.CreateCloudFrontInvalidation(ctx, *awsConfig, target.Id)
+				recordAudit(ctx, "cloudfront invalidation", []string{target.Id}, map[string]string{"paths": "/*"}, err)
 				if err != nil {
 					internal.RealPanic(internal.WrapError(err))
 				}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+const (
+	// 원격 경로 표시 (예: :/var/log/app.log)
+	remotePathPrefix        = ":"
+	transferRefreshInterval = 200 * time.Millisecond
+)
+
+var (
+	cpCommand = &cobra.Command{
+		Use:   "cp <source> <destination>",
+		Short: "Copy files between local disk and ec2 instances through the bastion",
+		Long: `Copy files and directories between local disk and one or many ec2 instances over SFTP through the bastion.
+Prefix the remote side with ':' (e.g. mcl cp -g web :/var/log/app.log ./logs).
+Downloading from several instances stores each instance under <destination>/<name>_<id>.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			mustAwsConfig()
+
+			source, destination := args[0], args[1]
+			download := strings.HasPrefix(source, remotePathPrefix)
+			if download == strings.HasPrefix(destination, remotePathPrefix) {
+				internal.RealPanic(fmt.Errorf("exactly one of source or destination must be remote (prefixed with '%s')", remotePathPrefix))
+			}
+
+			targets := resolveTargetSet(ctx, viper.GetString("cp-target"), viper.GetString("cp-group"), viper.GetString("cp-name"), "파일을 전송할 인스턴스를 선택하세요:")
+			bastion := resolveBastion(ctx)
+
+			bastionClient, err := internal.DialBastion(bastion)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			defer bastionClient.Close()
+
+			keepAliveCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go internal.KeepAlive(keepAliveCtx, bastionClient, internal.KeepAliveInterval)
+
+			options := internal.TransferOptions{
+				User:      viper.GetString("cp-user"),
+				Recursive: viper.GetBool("cp-recursive"),
+				Resume:    viper.GetBool("cp-resume"),
+			}
+
+			progresses := make(map[string]*internal.TransferProgress, len(targets))
+			ordered := make([]*internal.TransferProgress, 0, len(targets))
+			for _, target := range targets {
+				progress := internal.NewTransferProgress(target)
+				progresses[target.Id] = progress
+				ordered = append(ordered, progress)
+			}
+
+			operation := "upload"
+			if download {
+				operation = "download"
+			}
+
+			resultsCh := make(chan []internal.BatchResult, 1)
+			go func() {
+				resultsCh <- internal.RunBatch(ctx, targets, viper.GetInt("cp-concurrency"), func(ctx context.Context, target *internal.Target) (string, error) {
+					progress := progresses[target.Id]
+					var err error
+					if download {
+						err = internal.DownloadFromTarget(bastionClient, target, strings.TrimPrefix(source, remotePathPrefix), downloadDestination(destination, target, len(targets)), options, progress)
+					} else {
+						err = internal.UploadToTarget(bastionClient, target, source, strings.TrimPrefix(destination, remotePathPrefix), options, progress)
+					}
+					if err != nil {
+						progress.SetState(internal.TransferStateFailed)
+						return "", err
+					}
+					progress.SetState(internal.TransferStateDone)
+					return "", nil
+				})
+			}()
+
+			// 성공한 인스턴스는 진행률에 이미 표시되어 실패만 상세 출력
+			results := renderTransfers(ordered, resultsCh)
+			failed := 0
+			for _, result := range results {
+				if result.Err != nil {
+					internal.PrintBatchResult("cp", result)
+					failed++
+				}
+			}
+			internal.LogBatchSummary("cp", operation, len(results)-failed, failed)
+
+			if !download {
+				var actionErr error
+				if failed > 0 {
+					actionErr = fmt.Errorf("%d of %d instances failed", failed, len(results))
+				}
+				recordAudit(ctx, "cp upload", batchTargetIds(results), map[string]string{
+					"source":      source,
+					"destination": destination,
+				}, actionErr)
+			}
+		},
+	}
+)
+
+// 여러 인스턴스에서 받을 때는 인스턴스별 하위 디렉토리에 저장
+func downloadDestination(destination string, target *internal.Target, count int) string {
+	if count <= 1 {
+		return destination
+	}
+
+	dir := target.Id
+	if target.Name != "" {
+		dir = target.Name + "_" + target.Id
+	}
+	dir = filepath.Join(destination, dir)
+	os.MkdirAll(dir, 0755)
+	return dir
+}
+
+// 전송이 끝날 때까지 인스턴스별 진행률 갱신 출력
+func renderTransfers(progresses []*internal.TransferProgress, resultsCh <-chan []internal.BatchResult) []internal.BatchResult {
+	interactive := term.IsTerminal(int(os.Stdout.Fd()))
+	draw := func(redraw bool) {
+		if redraw {
+			fmt.Printf("\033[%dA", len(progresses))
+		}
+		for _, progress := range progresses {
+			if redraw {
+				fmt.Print("\033[2K")
+			}
+			internal.PrintTransferProgress("cp", progress)
+		}
+	}
+
+	if !interactive {
+		results := <-resultsCh
+		draw(false)
+		return results
+	}
+
+	draw(false)
+	ticker := time.NewTicker(transferRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			draw(true)
+		case results := <-resultsCh:
+			draw(true)
+			return results
+		}
+	}
+}
+
+func init() {
+	cpCommand.Flags().StringP("target", "t", "", "ec2 instanceIds, comma separated")
+	cpCommand.Flags().StringP("group", "g", "", "ec2 instance group (value of the group tag, glob allowed)")
+	cpCommand.Flags().StringP("name", "n", "", "ec2 instance Name tag (glob allowed)")
+	cpCommand.Flags().StringP("user", "u", "", "remote user (default ec2-user)")
+	cpCommand.Flags().BoolP("recursive", "R", false, "copy directories recursively")
+	cpCommand.Flags().Bool("resume", false, "continue interrupted downloads from the <file>.mcl-part they left behind")
+	cpCommand.Flags().Int("concurrency", internal.DefaultBatchConcurrency, "maximum number of instances transferring at the same time")
+	viper.BindPFlag("cp-target", cpCommand.Flags().Lookup("target"))
+	viper.BindPFlag("cp-group", cpCommand.Flags().Lookup("group"))
+	viper.BindPFlag("cp-name", cpCommand.Flags().Lookup("name"))
+	viper.BindPFlag("cp-user", cpCommand.Flags().Lookup("user"))
+	viper.BindPFlag("cp-recursive", cpCommand.Flags().Lookup("recursive"))
+	viper.BindPFlag("cp-resume", cpCommand.Flags().Lookup("resume"))
+	viper.BindPFlag("cp-concurrency", cpCommand.Flags().Lookup("concurrency"))
+
+	rootCmd.AddCommand(cpCommand)
+}
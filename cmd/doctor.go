@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorCommand = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the local environment and AWS permissions for mcl",
+		Long:  "Diagnose required binaries, ssh keys, credentials, clock skew and the IAM permissions each mcl feature needs",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+
+			// 전역 AWS Config 사용
+			awsConfig := GetGlobalAwsConfig()
+			if awsConfig == nil {
+				internal.RealPanic(fmt.Errorf("AWS config not initialized"))
+			}
+
+			var checks []internal.DoctorCheck
+			section := func(title string, results ...internal.DoctorCheck) {
+				internal.LogInfo(title)
+				for _, check := range results {
+					internal.PrintDoctorCheck(check)
+				}
+				checks = append(checks, results...)
+			}
+
+			section("Binaries", internal.CheckBinaries()...)
+
+			credentialCheck, callerArn := internal.CheckCredentials(ctx, *awsConfig)
+			section("AWS", credentialCheck, internal.CheckClockSkew(ctx, awsConfig.Region))
+
+			if callerArn == "" {
+				internal.LogWarning("자격 증명이 유효하지 않아 SSH 키와 IAM 권한 점검을 건너뜁니다.")
+			} else {
+				section("SSH keys", internal.CheckKeyFiles(ctx, *awsConfig)...)
+				section("IAM permissions", internal.CheckFeaturePermissions(ctx, *awsConfig, callerArn)...)
+			}
+
+			counts := make(map[internal.DoctorStatus]int)
+			for _, check := range checks {
+				counts[check.Status]++
+			}
+			summary := fmt.Sprintf("%d passed, %d warnings, %d failed, %d skipped",
+				counts[internal.DoctorPass], counts[internal.DoctorWarn], counts[internal.DoctorFail], counts[internal.DoctorSkip])
+			// 스크립트, CI 에서 사용할 수 있도록 실패가 있으면 0 이 아닌 종료 코드
+			if counts[internal.DoctorFail] > 0 {
+				internal.LogError(summary)
+				os.Exit(1)
+			}
+			internal.LogSuccess(summary)
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(doctorCommand)
+}
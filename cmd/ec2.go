@@ -16,10 +16,6 @@ var (
 		Short: "Exec `ec2 list` under AWS with interactive CLI",
 		Long:  "Exec `ec2 list` under AWS with interactive CLI",
 		Run: func(cmd *cobra.Command, args []string) {
-			var (
-				target *internal.Target
-				err    error
-			)
 			ctx := context.Background()
 
 			// 전역 AWS Config 사용
@@ -28,52 +24,109 @@ var (
 				internal.RealPanic(fmt.Errorf("AWS config not initialized"))
 			}
 
-			argTarget := strings.TrimSpace(viper.GetString("ec2-target"))
-			if argTarget != "" {
-				table, err := internal.FindInstance(ctx, *awsConfig)
-				if err != nil {
-					internal.RealPanic(internal.WrapError(err))
-				}
-				for _, t := range table {
-					if t.Id == argTarget {
-						target = t
-						break
-					}
-				}
+			states, err := internal.ParseInstanceStates(viper.GetString("ec2-state"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			filter, err := ec2InstanceFilter(states)
+			if err != nil {
+				internal.RealPanic(err)
 			}
 
+			// --target, --group 은 일치하는 모든 인스턴스 출력
+			argTarget := strings.TrimSpace(viper.GetString("ec2-target"))
 			argGroup := strings.TrimSpace(viper.GetString("ec2-group"))
-			if argGroup != "" {
-				table, err := internal.FindInstance(ctx, *awsConfig)
-				if err != nil {
-					internal.RealPanic(internal.WrapError(err))
+			if argTarget != "" || argGroup != "" {
+				targets := findEc2Targets(ctx, filter, argTarget)
+				if len(targets) == 0 {
+					internal.LogWarning("조건에 맞는 인스턴스가 없습니다.")
+					return
 				}
-
-				for _, t := range table {
-					if t.Group == argGroup {
-						target = t
-						break
-					}
+				for _, t := range targets {
+					internal.PrintEc2Target("ec2", awsConfig.Region, t)
 				}
+				return
 			}
 
-			if target == nil {
-				target, err = internal.AskTarget(ctx, *awsConfig)
-				if err != nil {
-					internal.RealPanic(err)
-				}
+			target, err := internal.AskTargetWithFilter(ctx, *awsConfig, filter)
+			if err != nil {
+				internal.RealPanic(err)
 			}
 
-			internal.PrintEc2("ec2", awsConfig.Region, target.Name, target.Id, target.PublicIp, target.PrivateIp)
+			internal.PrintEc2Target("ec2", awsConfig.Region, target)
 		},
 	}
 )
 
+// --tag, --group 을 EC2 서버 측 필터로 변환
+func ec2InstanceFilter(states []string) (internal.InstanceFilter, error) {
+	tags, err := internal.ParseTagFilters(viper.GetStringSlice("ec2-tag"))
+	if err != nil {
+		return internal.InstanceFilter{}, err
+	}
+
+	if group := strings.TrimSpace(viper.GetString("ec2-group")); group != "" {
+		tags = append(tags, internal.TagFilter{Key: internal.GroupTag(), Value: group})
+	}
+	return internal.InstanceFilter{States: states, Tags: tags}, nil
+}
+
+// --target(콤마 구분 ID) 또는 --group 에 해당하는 인스턴스 전체, 없으면 선택
+func resolveEc2Targets(ctx context.Context, states []string, message string) []*internal.Target {
+	filter, err := ec2InstanceFilter(states)
+	if err != nil {
+		internal.RealPanic(err)
+	}
+
+	argTarget := strings.TrimSpace(viper.GetString("ec2-target"))
+	argGroup := strings.TrimSpace(viper.GetString("ec2-group"))
+	if argTarget == "" && argGroup == "" {
+		targets, err := internal.AskTargets(ctx, *GetGlobalAwsConfig(), filter, message)
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		internal.SortTargets(targets)
+		return targets
+	}
+
+	return findEc2Targets(ctx, filter, argTarget)
+}
+
+// 필터에 맞는 인스턴스 중 ID 목록에 포함된 것 (ID 목록이 비어 있으면 전체)
+func findEc2Targets(ctx context.Context, filter internal.InstanceFilter, ids string) []*internal.Target {
+	table, err := internal.FindInstanceWithFilter(ctx, *GetGlobalAwsConfig(), filter)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+
+	idSet := make(map[string]struct{})
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			idSet[id] = struct{}{}
+		}
+	}
+
+	targets := make([]*internal.Target, 0, len(table))
+	for _, t := range table {
+		if _, ok := idSet[t.Id]; ok || len(idSet) == 0 {
+			targets = append(targets, t)
+		}
+	}
+	internal.SortTargets(targets)
+	return targets
+}
+
 func init() {
-	startEc2Command.Flags().StringP("target", "t", "", "ec2 instanceId")
-	startEc2Command.Flags().StringP("group", "g", "", "ec2 instance server group")
-	viper.BindPFlag("ec2-target", startEc2Command.Flags().Lookup("target"))
-	viper.BindPFlag("ec2-group", startEc2Command.Flags().Lookup("group"))
+	startEc2Command.PersistentFlags().StringP("target", "t", "", "ec2 instanceId")
+	startEc2Command.PersistentFlags().StringP("group", "g", "", "ec2 instance group (value of the group tag, glob allowed)")
+	startEc2Command.PersistentFlags().StringArray("tag", nil, "filter by tag key=value (repeatable, glob values with * and ?)")
+	viper.BindPFlag("ec2-target", startEc2Command.PersistentFlags().Lookup("target"))
+	viper.BindPFlag("ec2-group", startEc2Command.PersistentFlags().Lookup("group"))
+	startEc2Command.PersistentFlags().Int("concurrency", internal.DefaultBatchConcurrency, "maximum number of instances processed at the same time")
+	viper.BindPFlag("ec2-tag", startEc2Command.PersistentFlags().Lookup("tag"))
+	viper.BindPFlag("ec2-concurrency", startEc2Command.PersistentFlags().Lookup("concurrency"))
+	startEc2Command.Flags().String("state", "running", "instance states to list, comma separated (pending, running, stopping, stopped, shutting-down, terminated or all)")
+	viper.BindPFlag("ec2-state", startEc2Command.Flags().Lookup("state"))
 
 	rootCmd.AddCommand(startEc2Command)
 }
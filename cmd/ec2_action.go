@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ec2ActionCommands = []struct {
+		action internal.InstanceAction
+		short  string
+	}{
+		{action: internal.InstanceActionStart, short: "Start stopped ec2 instances"},
+		{action: internal.InstanceActionStop, short: "Stop running ec2 instances"},
+		{action: internal.InstanceActionReboot, short: "Reboot running ec2 instances"},
+		{action: internal.InstanceActionHibernate, short: "Hibernate running ec2 instances"},
+		{action: internal.InstanceActionTerminate, short: "Terminate ec2 instances (termination protection is respected)"},
+	}
+)
+
+func newEc2ActionCommand(action internal.InstanceAction, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   string(action),
+		Short: short,
+		Long:  fmt.Sprintf("%s. Targets come from --target, --group or a multi-select picker.", short),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+
+			// 전역 AWS Config 사용
+			awsConfig := GetGlobalAwsConfig()
+			if awsConfig == nil {
+				internal.RealPanic(fmt.Errorf("AWS config not initialized"))
+			}
+
+			targets := resolveEc2Targets(ctx, action.SourceStates(), fmt.Sprintf("%s 할 인스턴스를 선택하세요:", action))
+			if len(targets) == 0 {
+				internal.LogWarning("%s 가능한 상태(%s)의 인스턴스가 없습니다.", action, strings.Join(action.SourceStates(), ", "))
+				return
+			}
+
+			targets = confirmInstanceAction(action, targets)
+			if len(targets) == 0 {
+				internal.LogWarning("%s 작업을 취소했습니다.", action)
+				return
+			}
+
+			timeout := viper.GetDuration("ec2-wait-timeout")
+			internal.LogInfo("%d개 인스턴스에 %s 실행 중... (목표 상태: %s)", len(targets), action, action.TargetState())
+			results := internal.RunInstanceActions(ctx, *awsConfig, action, targets, viper.GetInt("ec2-concurrency"), timeout)
+
+			instanceIds := make([]string, 0, len(results))
+			var failed []string
+			counts := make(map[string]int)
+			for _, result := range results {
+				internal.PrintInstanceActionResult("ec2", result)
+				instanceIds = append(instanceIds, result.Target.Id)
+				counts[result.Status]++
+				if result.Status == internal.ActionStatusFailed {
+					failed = append(failed, fmt.Sprintf("%s: %v", result.Target.Id, result.Err))
+				}
+			}
+
+			var actionErr error
+			if len(failed) > 0 {
+				actionErr = fmt.Errorf("%s", strings.Join(failed, "; "))
+			}
+			recordAudit(ctx, "ec2 "+string(action), instanceIds, map[string]string{
+				"timeout": timeout.String(),
+				"skipped": fmt.Sprintf("%d", counts[internal.ActionStatusSkipped]),
+			}, actionErr)
+
+			summary := fmt.Sprintf("%s: %d succeeded, %d skipped, %d failed", action,
+				counts[internal.ActionStatusSuccess], counts[internal.ActionStatusSkipped], counts[internal.ActionStatusFailed])
+			// 스크립트에서 사용할 수 있도록 실패가 있으면 0 이 아닌 종료 코드 (메뉴에서는 메뉴로 복귀)
+			if len(failed) > 0 {
+				internal.RealPanic(errors.New(summary))
+			}
+			internal.LogSuccess(summary)
+		},
+	}
+}
+
+// 등록된 ec2 액션 서브커맨드 조회 (메뉴에서 사용)
+func ec2ActionCommand(action internal.InstanceAction) *cobra.Command {
+	for _, c := range startEc2Command.Commands() {
+		if c.Name() == string(action) {
+			return c
+		}
+	}
+	return nil
+}
+
+// 작업 확인 (terminate는 인스턴스 이름을 직접 입력해야 진행)
+func confirmInstanceAction(action internal.InstanceAction, targets []*internal.Target) []*internal.Target {
+	internal.LogWarning("%s 대상 인스턴스:", action)
+	for _, target := range targets {
+		internal.PrintEc2Target("ec2", GetGlobalRegion(), target)
+	}
+
+	if action == internal.InstanceActionTerminate {
+		confirmed := make([]*internal.Target, 0, len(targets))
+		for _, target := range targets {
+			expected := target.Name
+			if expected == "" {
+				expected = target.Id
+			}
+			ok, err := internal.AskTypedConfirm(fmt.Sprintf("%s (%s)을(를) 종료하려면", target.Name, target.Id), expected)
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			if !ok {
+				internal.LogWarning("입력한 이름이 일치하지 않아 건너뜁니다: %s", target.Id)
+				continue
+			}
+			confirmed = append(confirmed, target)
+		}
+		return confirmed
+	}
+
+	if viper.GetBool("ec2-yes") {
+		return targets
+	}
+
+	var proceed bool
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("%d개 인스턴스에 %s 을(를) 실행하시겠습니까?", len(targets), action),
+		Default: false,
+	}
+	if err := survey.AskOne(prompt, &proceed); err != nil {
+		internal.RealPanic(err)
+	}
+	if !proceed {
+		return nil
+	}
+	return targets
+}
+
+func init() {
+	startEc2Command.PersistentFlags().Bool("yes", false, "skip the confirmation prompt for batch operations (terminate always asks for the instance name)")
+	startEc2Command.PersistentFlags().Duration("wait-timeout", 10*time.Minute, "maximum time to wait for each instance (target state or command result)")
+	viper.BindPFlag("ec2-yes", startEc2Command.PersistentFlags().Lookup("yes"))
+	viper.BindPFlag("ec2-wait-timeout", startEc2Command.PersistentFlags().Lookup("wait-timeout"))
+
+	for _, c := range ec2ActionCommands {
+		startEc2Command.AddCommand(newEc2ActionCommand(c.action, c.short))
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ec2PrintCommand = &cobra.Command{
+		Use:   "print",
+		Short: "Print every ec2 instance in the selected set",
+		Long:  "Print every ec2 instance selected by --target, --group or --tag with its attributes",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			states, err := internal.ParseInstanceStates(viper.GetString("ec2-print-state"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			filter, err := ec2InstanceFilter(states)
+			if err != nil {
+				internal.RealPanic(err)
+			}
+
+			targets := findEc2Targets(ctx, filter, viper.GetString("ec2-target"))
+			for _, target := range targets {
+				internal.PrintEc2Target("ec2", awsConfig.Region, target)
+			}
+			internal.LogInfo("총 %d개 인스턴스", len(targets))
+		},
+	}
+
+	ec2SsmCommand = &cobra.Command{
+		Use:   "ssm",
+		Short: "Open SSM sessions to instances in the selected set",
+		Long:  "Pick an instance from the selected set and open an SSM session; returns to the picker when the session ends",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			if ok, missing := internal.CheckSSMClientInstalled(); !ok {
+				internal.LogWarning("SSM 클라이언트가 설치되어 있지 않습니다.")
+				internal.PrintSSMInstallGuide(missing)
+				return
+			}
+
+			filter, err := ec2InstanceFilter([]string{"running"})
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			targets := findEc2Targets(ctx, filter, viper.GetString("ec2-target"))
+			if len(targets) == 0 {
+				internal.LogWarning("실행 중인 인스턴스가 없습니다.")
+				return
+			}
+
+			options := make([]string, 0, len(targets)+1)
+			optionMap := make(map[string]*internal.Target, len(targets))
+			for _, target := range targets {
+				option := target.Summary()
+				options = append(options, option)
+				optionMap[option] = target
+			}
+			options = append(options, menuExit)
+
+			for {
+				selected, err := internal.AskMenu("SSM으로 접속할 인스턴스를 선택하세요:", options)
+				if err != nil || selected == menuExit {
+					return
+				}
+
+				target := optionMap[selected]
+				internal.LogInfo("SSM 세션을 시작합니다: %s (%s)", target.Name, target.Id)
+				if err := internal.StartSSMSession(ctx, target.Id, awsConfig.Region); err != nil {
+					internal.LogError("SSM 세션 연결 실패: %v", err)
+				}
+			}
+		},
+	}
+
+	ec2RunCommand = &cobra.Command{
+		Use:   "run -- <command>",
+		Short: "Run a shell command on the selected set through SSM Run Command",
+		Long:  "Run a shell command on every instance in the selected set through SSM Run Command and report the result of each instance",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			command := strings.Join(args, " ")
+			targets := resolveEc2Targets(ctx, []string{"running"}, "명령을 실행할 인스턴스를 선택하세요:")
+			if len(targets) == 0 {
+				internal.LogWarning("실행 중인 인스턴스가 없습니다.")
+				return
+			}
+
+			if !confirmBatch(fmt.Sprintf("%d개 인스턴스에서 '%s' 을(를) 실행하시겠습니까?", len(targets), command)) {
+				internal.LogWarning("명령 실행을 취소했습니다.")
+				return
+			}
+
+			timeout := viper.GetDuration("ec2-wait-timeout")
+			results := internal.RunBatch(ctx, targets, viper.GetInt("ec2-concurrency"), func(ctx context.Context, target *internal.Target) (string, error) {
+				return internal.RunSSMCommand(ctx, *awsConfig, target, command, timeout)
+			})
+			failed := internal.PrintBatchReport("ec2", "run", results)
+
+			var actionErr error
+			if failed > 0 {
+				actionErr = fmt.Errorf("%d of %d instances failed", failed, len(results))
+			}
+			recordAudit(ctx, "ec2 run", batchTargetIds(results), map[string]string{"command": command}, actionErr)
+
+			// 스크립트에서 사용할 수 있도록 실패가 있으면 0 이 아닌 종료 코드
+			if failed > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	ec2VolumeCommand = &cobra.Command{
+		Use:   "volume",
+		Short: "Check root volume usage of the selected set through the bastion",
+		Long:  "Check root volume usage of every instance in the selected set through the bastion",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			mustAwsConfig()
+
+			targets := resolveEc2Targets(ctx, []string{"running"}, "볼륨 사용량을 확인할 인스턴스를 선택하세요:")
+			if len(targets) == 0 {
+				internal.LogWarning("실행 중인 인스턴스가 없습니다.")
+				return
+			}
+
+			bastion := resolveBastion(ctx)
+			bastionClient, err := internal.ConnectionBastion(bastion)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			results := internal.RunBatch(ctx, targets, viper.GetInt("ec2-concurrency"), func(ctx context.Context, target *internal.Target) (string, error) {
+				usage, err := internal.GetVolumeUsageWithTimeout(ctx, func(bastion *ssh.Client, target *internal.Target) (int, error) {
+					return internal.GetVolumeUsage(bastion, target)
+				}, 10*time.Second, bastionClient, target)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("usage: %d%%", usage), nil
+			})
+			if failed := internal.PrintBatchReport("ec2", "volume check", results); failed > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func mustAwsConfig() *aws.Config {
+	awsConfig := GetGlobalAwsConfig()
+	if awsConfig == nil {
+		internal.RealPanic(fmt.Errorf("AWS config not initialized"))
+	}
+	return awsConfig
+}
+
+// --yes 가 없으면 일괄 작업 전 확인
+func confirmBatch(message string) bool {
+	if viper.GetBool("ec2-yes") {
+		return true
+	}
+
+	var proceed bool
+	if err := survey.AskOne(&survey.Confirm{Message: message, Default: false}, &proceed); err != nil {
+		internal.RealPanic(err)
+	}
+	return proceed
+}
+
+func batchTargetIds(results []internal.BatchResult) []string {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Target.Id)
+	}
+	return ids
+}
+
+func init() {
+	ec2PrintCommand.Flags().String("state", "running", "instance states to print, comma separated (pending, running, stopping, stopped, shutting-down, terminated or all)")
+	viper.BindPFlag("ec2-print-state", ec2PrintCommand.Flags().Lookup("state"))
+
+	startEc2Command.AddCommand(ec2PrintCommand, ec2SsmCommand, ec2RunCommand, ec2VolumeCommand)
+}
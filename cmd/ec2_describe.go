@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ec2DescribeCommand = &cobra.Command{
+		Use:   "describe [instance-id|name]",
+		Short: "Show everything about an ec2 instance, including the console output",
+		Long:  "Show instance attributes, attached volumes, ENIs, security group rules, IAM role, tags, status checks and the serial console output. --follow keeps polling the console log (useful during boot problems)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			var query string
+			if len(args) > 0 {
+				query = args[0]
+			}
+			target := resolveDescribeTarget(ctx, query)
+
+			detail := internal.DescribeInstanceDetail(ctx, *awsConfig, target)
+			internal.PrintInstanceDetail("ec2", awsConfig.Region, detail)
+
+			lines := viper.GetInt("ec2-describe-console-lines")
+			follow := viper.GetBool("ec2-describe-follow")
+			if lines == 0 && !follow {
+				return
+			}
+
+			output, timestamp, err := internal.GetConsoleOutput(ctx, *awsConfig, target.Id)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			if timestamp.IsZero() {
+				internal.LogSection("Console Output")
+			} else {
+				internal.LogSection(fmt.Sprintf("Console Output (%s)", timestamp.Local().Format("2006-01-02 15:04:05")))
+			}
+			if output == "" {
+				internal.LogWarning("콘솔 출력이 아직 없습니다")
+			} else {
+				fmt.Print(internal.TailLines(output, lines))
+			}
+
+			if !follow {
+				return
+			}
+			internal.LogInfo("콘솔 로그를 %s 마다 조회합니다 (Ctrl+C 로 종료)", viper.GetDuration("ec2-describe-interval"))
+			followCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+			if err := internal.FollowConsoleOutput(followCtx, *awsConfig, target.Id, output, viper.GetDuration("ec2-describe-interval"), os.Stdout); err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+		},
+	}
+)
+
+// 인자(ID 또는 Name glob), --target/--group, 선택 순으로 인스턴스 하나 결정 (종료되지 않은 모든 상태)
+func resolveDescribeTarget(ctx context.Context, query string) *internal.Target {
+	states, err := internal.ParseInstanceStates("all")
+	if err != nil {
+		internal.RealPanic(err)
+	}
+	filter, err := ec2InstanceFilter(states)
+	if err != nil {
+		internal.RealPanic(err)
+	}
+
+	var targets []*internal.Target
+	switch {
+	case query != "":
+		for _, t := range findEc2Targets(ctx, filter, "") {
+			if matched, _ := path.Match(query, t.Name); t.Id == query || matched {
+				targets = append(targets, t)
+			}
+		}
+	case strings.TrimSpace(viper.GetString("ec2-target")) != "" || strings.TrimSpace(viper.GetString("ec2-group")) != "":
+		targets = findEc2Targets(ctx, filter, viper.GetString("ec2-target"))
+	default:
+		target, err := internal.AskTargetWithFilter(ctx, *mustAwsConfig(), filter)
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		return target
+	}
+
+	if len(targets) == 0 {
+		internal.RealPanic(fmt.Errorf("no instance matches query=%q target=%q group=%q", query, viper.GetString("ec2-target"), viper.GetString("ec2-group")))
+	}
+	target, err := internal.AskTargetFrom(targets, "조회할 인스턴스를 선택하세요:")
+	if err != nil {
+		internal.RealPanic(err)
+	}
+	return target
+}
+
+func init() {
+	ec2DescribeCommand.Flags().Int("console-lines", 50, "number of console output lines to show (0 to skip, -1 for all)")
+	ec2DescribeCommand.Flags().BoolP("follow", "f", false, "keep polling the serial console log")
+	ec2DescribeCommand.Flags().Duration("interval", 10*time.Second, "console polling interval for --follow")
+	viper.BindPFlag("ec2-describe-console-lines", ec2DescribeCommand.Flags().Lookup("console-lines"))
+	viper.BindPFlag("ec2-describe-follow", ec2DescribeCommand.Flags().Lookup("follow"))
+	viper.BindPFlag("ec2-describe-interval", ec2DescribeCommand.Flags().Lookup("interval"))
+
+	startEc2Command.AddCommand(ec2DescribeCommand)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ec2GroupsCommand = &cobra.Command{
+		Use:   "groups",
+		Short: "List ec2 instance groups with instance counts",
+		Long:  "List the distinct values of the group tag (--group-tag, default Server-Group) with instance counts",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+
+			// 전역 AWS Config 사용
+			awsConfig := GetGlobalAwsConfig()
+			if awsConfig == nil {
+				internal.RealPanic(fmt.Errorf("AWS config not initialized"))
+			}
+
+			states, err := internal.ParseInstanceStates(viper.GetString("ec2-groups-state"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			filter, err := ec2InstanceFilter(states)
+			if err != nil {
+				internal.RealPanic(err)
+			}
+
+			groups, err := internal.CountInstanceGroups(ctx, *awsConfig, filter)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			if len(groups) == 0 {
+				internal.LogWarning("조건에 맞는 인스턴스가 없습니다.")
+				return
+			}
+
+			names := make([]string, 0, len(groups))
+			for name := range groups {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				internal.PrintInstanceGroup("ec2", internal.GroupTag(), name, groups[name])
+			}
+		},
+	}
+)
+
+func init() {
+	ec2GroupsCommand.Flags().String("state", "running", "instance states to count, comma separated (pending, running, stopping, stopped, shutting-down, terminated or all)")
+	viper.BindPFlag("ec2-groups-state", ec2GroupsCommand.Flags().Lookup("state"))
+
+	startEc2Command.AddCommand(ec2GroupsCommand)
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	launchFromTemplate = "launch template 으로 시작"
+	launchFromInstance = "기존 인스턴스 설정 복제"
+)
+
+var (
+	ec2LaunchCommand = &cobra.Command{
+		Use:   "launch",
+		Short: "Launch an instance from a launch template or by cloning an existing instance",
+		Long:  "Pick a launch template and version (or clone an existing instance's AMI, type, subnet, security groups, IAM profile, volumes, user data and tags), override type, subnet, name and tags, launch one instance, wait for running and status OK and offer an SSM session. --ttl tags the instance with an expiry (TTL-Expires, TTL-Action) for auto-stop/terminate automation",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			tags, err := internal.ParseTags(viper.GetStringSlice("ec2-launch-tag"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			ttlAction := strings.ToLower(strings.TrimSpace(viper.GetString("ec2-launch-ttl-action")))
+			if ttlAction != internal.TTLActionStop && ttlAction != internal.TTLActionTerminate {
+				internal.RealPanic(fmt.Errorf("invalid --ttl-action %q (stop, terminate)", ttlAction))
+			}
+
+			options := internal.LaunchOptions{
+				InstanceType: strings.TrimSpace(viper.GetString("ec2-launch-type")),
+				Name:         strings.TrimSpace(viper.GetString("ec2-launch-name")),
+				Tags:         tags,
+				TTL:          viper.GetDuration("ec2-launch-ttl"),
+				TTLAction:    ttlAction,
+			}
+
+			source, baseName := resolveLaunchSource(ctx, &options)
+			if options.Name == "" {
+				options.Name = baseName + "-" + time.Now().Format("0102-1504")
+			}
+			if subnet := strings.TrimSpace(viper.GetString("ec2-launch-subnet")); subnet != "" {
+				options.Subnet, err = internal.ResolveSubnet(ctx, *awsConfig, subnet)
+				if err != nil {
+					internal.RealPanic(internal.WrapError(err))
+				}
+				if err := internal.CheckCloneSubnet(options.Source, options.Subnet); err != nil {
+					internal.RealPanic(err)
+				}
+			}
+
+			attributes := [][2]string{{"Source", source}, {"Name", options.Name}}
+			if options.InstanceType != "" {
+				attributes = append(attributes, [2]string{"Type", options.InstanceType})
+			}
+			if options.Subnet != nil {
+				attributes = append(attributes, [2]string{"Subnet", fmt.Sprintf("%s (%s, %s)", options.Subnet.Name, options.Subnet.Id, options.Subnet.AvailabilityZone)})
+			}
+			if options.TTL > 0 {
+				attributes = append(attributes, [2]string{"TTL", fmt.Sprintf("%s 후 %s", options.TTL, options.TTLAction)})
+			}
+			internal.LogSection("Launch")
+			internal.LogAttributes(attributes)
+			if !confirmBatch("인스턴스를 시작하시겠습니까?") {
+				internal.LogWarning("작업이 취소되었습니다.")
+				return
+			}
+
+			instanceId, err := internal.LaunchInstance(ctx, *awsConfig, options)
+			params := map[string]string{"source": source, "name": options.Name, "type": options.InstanceType}
+			if options.TTL > 0 {
+				params["ttl"] = options.TTL.String()
+				params["ttlAction"] = options.TTLAction
+			}
+			recordAudit(ctx, "ec2 launch", []string{instanceId}, params, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("인스턴스를 시작했습니다: %s (%s)", options.Name, instanceId)
+
+			target, err := internal.WaitInstanceReady(ctx, *awsConfig, instanceId, viper.GetDuration("ec2-wait-timeout"), func(step string) {
+				internal.LogInfo("[%s] %s", options.Name, step)
+			})
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.PrintEc2Target("ec2", awsConfig.Region, target)
+			internal.LogAttributes(target.Attributes())
+
+			connect := viper.GetBool("ec2-launch-ssm")
+			if !connect && !viper.GetBool("ec2-yes") {
+				if err := survey.AskOne(&survey.Confirm{Message: "SSM 으로 접속하시겠습니까?", Default: true}, &connect); err != nil {
+					internal.RealPanic(err)
+				}
+			}
+			if !connect {
+				return
+			}
+			if ok, missing := internal.CheckSSMClientInstalled(); !ok {
+				internal.LogWarning("SSM 클라이언트가 설치되어 있지 않습니다.")
+				internal.PrintSSMInstallGuide(missing)
+				return
+			}
+			internal.LogInfo("SSM 세션을 시작합니다: %s (%s)", target.Name, target.Id)
+			if err := internal.StartSSMSession(ctx, target.Id, awsConfig.Region); err != nil {
+				internal.RealPanic(fmt.Errorf("SSM 세션 연결 실패: %w", err))
+			}
+		},
+	}
+)
+
+// --template / --from, 둘 다 없으면 선택. 원본 설명과 기본 이름에 쓸 원본 이름 반환
+func resolveLaunchSource(ctx context.Context, options *internal.LaunchOptions) (string, string) {
+	awsConfig := mustAwsConfig()
+	template := strings.TrimSpace(viper.GetString("ec2-launch-template"))
+	from := strings.TrimSpace(viper.GetString("ec2-launch-from"))
+	if template != "" && from != "" {
+		internal.RealPanic(fmt.Errorf("use either --template or --from"))
+	}
+
+	if template == "" && from == "" {
+		selected, err := internal.AskMenu("인스턴스를 어떻게 시작할까요?", []string{launchFromTemplate, launchFromInstance})
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		if selected == launchFromInstance {
+			options.Source = resolveDescribeTarget(ctx, "")
+			return fmt.Sprintf("%s (%s)", options.Source.Name, options.Source.Id), options.Source.Name
+		}
+	} else if from != "" {
+		options.Source = resolveDescribeTarget(ctx, from)
+		return fmt.Sprintf("%s (%s)", options.Source.Name, options.Source.Id), options.Source.Name
+	}
+
+	templates, err := internal.FindLaunchTemplates(ctx, *awsConfig)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	var selected *internal.LaunchTemplate
+	if template == "" {
+		if selected, err = internal.AskLaunchTemplate(templates, "launch template 을 선택하세요:"); err != nil {
+			internal.RealPanic(err)
+		}
+	} else {
+		for _, t := range templates {
+			if t.Id == template || t.Name == template {
+				selected = t
+			}
+		}
+		if selected == nil {
+			internal.RealPanic(fmt.Errorf("launch template %q not found", template))
+		}
+	}
+	options.TemplateId = selected.Id
+
+	version := strings.TrimSpace(viper.GetString("ec2-launch-version"))
+	if version == "" && template == "" {
+		versions, err := internal.FindLaunchTemplateVersions(ctx, *awsConfig, selected.Id)
+		if err != nil {
+			internal.RealPanic(internal.WrapError(err))
+		}
+		if version, err = internal.AskLaunchTemplateVersion(versions, "버전을 선택하세요:"); err != nil {
+			internal.RealPanic(err)
+		}
+	}
+	if version == "" {
+		version = "$Default"
+	}
+	options.TemplateVersion = version
+	return fmt.Sprintf("%s (%s, version %s)", selected.Name, selected.Id, version), selected.Name
+}
+
+func init() {
+	ec2LaunchCommand.Flags().String("template", "", "launch template name or id (picker when neither --template nor --from is given)")
+	ec2LaunchCommand.Flags().String("version", "", "launch template version: number, $Default or $Latest (default $Default)")
+	ec2LaunchCommand.Flags().String("from", "", "clone the configuration of this instance (id or Name, glob allowed)")
+	ec2LaunchCommand.Flags().String("type", "", "override the instance type")
+	ec2LaunchCommand.Flags().String("subnet", "", "override the subnet (id or Name tag, glob allowed)")
+	ec2LaunchCommand.Flags().String("name", "", "Name tag (default <source>-<MMdd-HHmm>)")
+	ec2LaunchCommand.Flags().StringArray("tag", nil, "add tag KEY=VALUE (repeatable)")
+	ec2LaunchCommand.Flags().Duration("ttl", 0, "tag the instance to expire after this duration (e.g. 8h)")
+	ec2LaunchCommand.Flags().String("ttl-action", internal.TTLActionStop, "action when the TTL expires (stop, terminate)")
+	ec2LaunchCommand.Flags().Bool("ssm", false, "open an SSM session when the instance is ready without asking")
+	viper.BindPFlag("ec2-launch-template", ec2LaunchCommand.Flags().Lookup("template"))
+	viper.BindPFlag("ec2-launch-version", ec2LaunchCommand.Flags().Lookup("version"))
+	viper.BindPFlag("ec2-launch-from", ec2LaunchCommand.Flags().Lookup("from"))
+	viper.BindPFlag("ec2-launch-type", ec2LaunchCommand.Flags().Lookup("type"))
+	viper.BindPFlag("ec2-launch-subnet", ec2LaunchCommand.Flags().Lookup("subnet"))
+	viper.BindPFlag("ec2-launch-name", ec2LaunchCommand.Flags().Lookup("name"))
+	viper.BindPFlag("ec2-launch-tag", ec2LaunchCommand.Flags().Lookup("tag"))
+	viper.BindPFlag("ec2-launch-ttl", ec2LaunchCommand.Flags().Lookup("ttl"))
+	viper.BindPFlag("ec2-launch-ttl-action", ec2LaunchCommand.Flags().Lookup("ttl-action"))
+	viper.BindPFlag("ec2-launch-ssm", ec2LaunchCommand.Flags().Lookup("ssm"))
+
+	startEc2Command.AddCommand(ec2LaunchCommand)
+}
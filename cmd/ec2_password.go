@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ec2PasswordCommand = &cobra.Command{
+		Use:   "password [instance-id|name]",
+		Short: "Decrypt the Windows Administrator password of an ec2 instance",
+		Long:  "Fetch the encrypted Windows password with GetPasswordData and decrypt it locally with ~/.ssh/<KeyName>.pem (RSA PKCS#1 v1.5). The key never leaves this machine",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			var query string
+			if len(args) > 0 {
+				query = args[0]
+			}
+			target := resolveDescribeTarget(ctx, query)
+			if target.Platform != "" && !strings.Contains(strings.ToLower(target.Platform), "windows") {
+				internal.RealPanic(fmt.Errorf("%s (%s) is not a Windows instance (%s)", target.Name, target.Id, target.Platform))
+			}
+
+			keyPath := strings.TrimSpace(viper.GetString("ec2-password-key"))
+			if keyPath == "" {
+				if target.KeyName == "" {
+					internal.RealPanic(fmt.Errorf("%s (%s) was launched without a key pair; use --key", target.Name, target.Id))
+				}
+				keyPath = internal.InstanceKeyPath(target)
+			}
+
+			password, err := internal.GetWindowsPassword(ctx, *awsConfig, target, keyPath)
+			recordAudit(ctx, "ec2 password", []string{target.Id}, map[string]string{
+				"copy": fmt.Sprintf("%t", viper.GetBool("ec2-password-copy")),
+			}, err)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			if viper.GetBool("ec2-password-copy") {
+				if err := internal.CopyToClipboard(password); err != nil {
+					internal.RealPanic(err)
+				}
+				internal.LogSuccess("%s (%s) 의 %s 비밀번호를 클립보드에 복사했습니다.", target.Name, target.Id, internal.WindowsAdminUser)
+				if viper.GetBool("ec2-password-rdp") {
+					internal.PrintRDPDetails(target, "")
+				}
+				return
+			}
+
+			if viper.GetBool("ec2-password-rdp") {
+				internal.PrintRDPDetails(target, password)
+				return
+			}
+			fmt.Println(password)
+		},
+	}
+)
+
+func init() {
+	ec2PasswordCommand.Flags().String("key", "", "private key used at launch (default ~/.ssh/<KeyName>.pem)")
+	ec2PasswordCommand.Flags().BoolP("copy", "c", false, "copy the password to the clipboard instead of printing it")
+	ec2PasswordCommand.Flags().Bool("rdp", false, "print the RDP connection details (host, user, password)")
+	viper.BindPFlag("ec2-password-key", ec2PasswordCommand.Flags().Lookup("key"))
+	viper.BindPFlag("ec2-password-copy", ec2PasswordCommand.Flags().Lookup("copy"))
+	viper.BindPFlag("ec2-password-rdp", ec2PasswordCommand.Flags().Lookup("rdp"))
+
+	startEc2Command.AddCommand(ec2PasswordCommand)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ec2ResizeCommand = &cobra.Command{
+		Use:   "resize",
+		Short: "Change the instance type (stop, modify, start) with compatibility checks and rollback",
+		Long:  "Validate architecture, ENA, NVMe and AZ availability for the new type, then stop, optionally create an AMI and wait until it is available, modify the type, start and wait for status checks. If the start fails the original type is restored. Multiple instances are resized in rolling batches of --batch-size, stopping at the first failed batch",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			targets := resolveEc2Targets(ctx, []string{"running", "stopped"}, "타입을 변경할 인스턴스를 선택하세요:")
+			if len(targets) == 0 {
+				internal.LogWarning("실행 중이거나 정지된 인스턴스가 없습니다.")
+				return
+			}
+
+			instanceType := strings.TrimSpace(viper.GetString("ec2-resize-type"))
+			if instanceType == "" {
+				if err := survey.AskOne(&survey.Input{Message: "새 인스턴스 타입:"}, &instanceType, survey.WithValidator(survey.Required)); err != nil {
+					internal.RealPanic(err)
+				}
+				instanceType = strings.TrimSpace(instanceType)
+			}
+			batchSize := viper.GetInt("ec2-resize-batch-size")
+			if batchSize < 1 {
+				internal.RealPanic(fmt.Errorf("--batch-size must be at least 1"))
+			}
+
+			checks, err := internal.CheckResize(ctx, *awsConfig, targets, instanceType)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			var valid []*internal.Target
+			for _, check := range checks {
+				for _, e := range check.Errors {
+					internal.LogError("%s (%s): %s", check.Target.Name, check.Target.Id, e)
+				}
+				for _, w := range check.Warnings {
+					internal.LogWarning("%s (%s): %s", check.Target.Name, check.Target.Id, w)
+				}
+				if len(check.Errors) == 0 {
+					valid = append(valid, check.Target)
+				}
+			}
+			if len(valid) == 0 {
+				internal.LogWarning("타입을 변경할 수 있는 인스턴스가 없습니다.")
+				return
+			}
+
+			for _, target := range valid {
+				internal.LogInfo("%s (%s): %s -> %s (%s)", target.Name, target.Id, target.InstanceType, instanceType, target.State)
+			}
+			if !confirmBatch(fmt.Sprintf("%d개 인스턴스를 %s 로 변경하시겠습니까? 실행 중인 인스턴스는 정지 후 다시 시작됩니다 (배치 크기: %d)", len(valid), instanceType, batchSize)) {
+				internal.LogWarning("작업이 취소되었습니다.")
+				return
+			}
+
+			options := internal.ResizeOptions{
+				InstanceType: instanceType,
+				CreateImage:  viper.GetBool("ec2-resize-ami"),
+				ImageTimeout: viper.GetDuration("ec2-resize-ami-timeout"),
+				WaitTimeout:  viper.GetDuration("ec2-wait-timeout"),
+			}
+			ids := make([]string, 0, len(valid))
+			originalTypes := make([]string, 0, len(valid))
+			for _, target := range valid {
+				ids = append(ids, target.Id)
+				originalTypes = append(originalTypes, target.InstanceType)
+			}
+
+			// 배치 단위로 순차 진행, 실패한 배치가 있으면 중단
+			var succeeded, failed int
+			var firstErr error
+			batches := (len(valid) + batchSize - 1) / batchSize
+			for i := 0; i < batches; i++ {
+				batch := valid[i*batchSize : min(len(valid), (i+1)*batchSize)]
+				internal.LogSection(fmt.Sprintf("Batch %d/%d", i+1, batches))
+
+				results := internal.RunBatch(ctx, batch, len(batch), func(ctx context.Context, target *internal.Target) (string, error) {
+					return internal.ResizeInstance(ctx, *awsConfig, target, options, func(step string) {
+						internal.LogInfo("[%s] %s", target.Name, step)
+					})
+				})
+				for _, result := range results {
+					internal.PrintBatchResult("ec2", result)
+					if result.Err != nil {
+						failed++
+						if firstErr == nil {
+							firstErr = fmt.Errorf("%s: %w", result.Target.Id, result.Err)
+						}
+						continue
+					}
+					succeeded++
+				}
+
+				if firstErr != nil && i < batches-1 {
+					internal.LogError("배치 %d 에서 실패가 발생해 남은 %d개 인스턴스는 진행하지 않습니다.", i+1, len(valid)-(i+1)*batchSize)
+					break
+				}
+			}
+
+			recordAudit(ctx, "ec2 resize", ids, map[string]string{
+				"type":      instanceType,
+				"from":      strings.Join(originalTypes, ","),
+				"batchSize": strconv.Itoa(batchSize),
+				"ami":       strconv.FormatBool(options.CreateImage),
+			}, firstErr)
+			internal.LogBatchSummary("ec2", "resize", succeeded, failed)
+
+			// 실패, 롤백, 중단된 배치가 있으면 0 이 아닌 종료 코드
+			if failed > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	ec2ResizeCommand.Flags().String("type", "", "new instance type (asked when omitted)")
+	ec2ResizeCommand.Flags().Bool("ami", false, "create an AMI after stopping (consistent, no extra reboot) and wait until it is available before changing the type")
+	ec2ResizeCommand.Flags().Duration("ami-timeout", 60*time.Minute, "maximum time to wait for the AMI (the instance stays stopped meanwhile)")
+	ec2ResizeCommand.Flags().Int("batch-size", 1, "number of instances resized at the same time (rolling)")
+	viper.BindPFlag("ec2-resize-type", ec2ResizeCommand.Flags().Lookup("type"))
+	viper.BindPFlag("ec2-resize-ami", ec2ResizeCommand.Flags().Lookup("ami"))
+	viper.BindPFlag("ec2-resize-ami-timeout", ec2ResizeCommand.Flags().Lookup("ami-timeout"))
+	viper.BindPFlag("ec2-resize-batch-size", ec2ResizeCommand.Flags().Lookup("batch-size"))
+
+	startEc2Command.AddCommand(ec2ResizeCommand)
+}
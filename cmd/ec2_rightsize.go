@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ec2RightsizeCommand = &cobra.Command{
+		Use:   "rightsize",
+		Short: "Find idle and underutilized instances and estimate savings",
+		Long:  "Pull CloudWatch CPUUtilization, NetworkIn/Out and CWAgent memory (when available) for running instances over --days, classify them as idle, underutilized or healthy, suggest one size smaller in the same family and estimate monthly savings from an embedded on-demand price table (us-east-1 Linux)",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			format := strings.ToLower(viper.GetString("ec2-rightsize-output"))
+			if err := internal.ValidateOutputFormat(format); err != nil {
+				internal.RealPanic(err)
+			}
+			days := viper.GetInt("ec2-rightsize-days")
+			if days < 1 {
+				internal.RealPanic(fmt.Errorf("--days must be at least 1"))
+			}
+
+			filter, err := ec2InstanceFilter([]string{"running"})
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			targets := findEc2Targets(ctx, filter, viper.GetString("ec2-target"))
+			if len(targets) == 0 {
+				internal.LogWarning("실행 중인 인스턴스가 없습니다.")
+				return
+			}
+
+			results, err := internal.AnalyzeRightsizing(ctx, *awsConfig, targets, internal.RightsizeOptions{
+				Window:      time.Duration(days) * 24 * time.Hour,
+				IdleCPU:     viper.GetFloat64("ec2-rightsize-idle-cpu"),
+				IdleNetwork: viper.GetFloat64("ec2-rightsize-idle-network") * 1024 * 1024,
+				UnderCPU:    viper.GetFloat64("ec2-rightsize-under-cpu"),
+				UnderMemory: viper.GetFloat64("ec2-rightsize-under-memory"),
+			})
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			headers, rows := internal.RightsizeTable(results)
+			if err := internal.PrintOutput(format, headers, rows, results); err != nil {
+				internal.RealPanic(err)
+			}
+			if format != internal.OutputTable {
+				return
+			}
+
+			counts := make(map[string]int)
+			var savings float64
+			for _, result := range results {
+				counts[result.Class]++
+				if result.MonthlySavings != nil {
+					savings += *result.MonthlySavings
+				}
+			}
+			internal.LogInfo("idle: %d, underutilized: %d, healthy: %d, no data: %d, 예상 절감액: $%.2f/월 (온디맨드 기준)",
+				counts[internal.RightsizeIdle], counts[internal.RightsizeUnderutilized], counts[internal.RightsizeHealthy], counts[internal.RightsizeNoData], savings)
+		},
+	}
+)
+
+func init() {
+	ec2RightsizeCommand.Flags().Int("days", 14, "metric window in days")
+	ec2RightsizeCommand.Flags().Float64("idle-cpu", 5, "idle when average CPU (%) is at or below this")
+	ec2RightsizeCommand.Flags().Float64("idle-network", 5, "idle when network in+out (MiB/day) is at or below this")
+	ec2RightsizeCommand.Flags().Float64("under-cpu", 40, "underutilized when peak hourly CPU (%) is at or below this")
+	ec2RightsizeCommand.Flags().Float64("under-memory", 50, "underutilized when peak hourly memory (%) is at or below this (CWAgent)")
+	ec2RightsizeCommand.Flags().StringP("output", "o", internal.OutputTable, "output format (table, json, csv)")
+	viper.BindPFlag("ec2-rightsize-days", ec2RightsizeCommand.Flags().Lookup("days"))
+	viper.BindPFlag("ec2-rightsize-idle-cpu", ec2RightsizeCommand.Flags().Lookup("idle-cpu"))
+	viper.BindPFlag("ec2-rightsize-idle-network", ec2RightsizeCommand.Flags().Lookup("idle-network"))
+	viper.BindPFlag("ec2-rightsize-under-cpu", ec2RightsizeCommand.Flags().Lookup("under-cpu"))
+	viper.BindPFlag("ec2-rightsize-under-memory", ec2RightsizeCommand.Flags().Lookup("under-memory"))
+	viper.BindPFlag("ec2-rightsize-output", ec2RightsizeCommand.Flags().Lookup("output"))
+
+	startEc2Command.AddCommand(ec2RightsizeCommand)
+}
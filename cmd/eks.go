@@ -80,7 +80,7 @@ var (
 					}
 
 					if runKubectl {
-						runKubectlCommands(ctx)
+						runKubectlCommands(ctx, selectedCluster)
 					}
 				}
 			}
@@ -88,7 +88,7 @@ var (
 	}
 )
 
-func runKubectlCommands(ctx context.Context) {
+func runKubectlCommands(ctx context.Context, clusterName string) {
 	// kubectl 명령어 선택
 	var kubectlOptions = []string{
 		"get nodes",
@@ -212,6 +212,7 @@ func runKubectlCommands(ctx context.Context) {
 			internal.RealPanic(err)
 		}
 		err := internal.ApplyKubectl(ctx, filePath)
+		recordAudit(ctx, "eks kubectl apply", []string{filePath}, map[string]string{"cluster": clusterName}, err)
 		if err != nil {
 			internal.RealPanic(internal.WrapError(err))
 		}
@@ -237,6 +238,10 @@ func runKubectlCommands(ctx context.Context) {
 			internal.RealPanic(err)
 		}
 		err := internal.DeleteKubectl(ctx, resourceType, resourceName, namespace)
+		recordAudit(ctx, "eks kubectl delete", []string{fmt.Sprintf("%s/%s", resourceType, resourceName)}, map[string]string{
+			"cluster":   clusterName,
+			"namespace": namespace,
+		}, err)
 		if err != nil {
 			internal.RealPanic(internal.WrapError(err))
 		}
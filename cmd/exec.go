@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	execCommand = &cobra.Command{
+		Use:   "exec -- <command>",
+		Short: "Run a command on many ec2 instances in parallel through the bastion",
+		Long:  "Run a shell command on every selected ec2 instance over SSH through the bastion, streaming host-prefixed output and ending with a summary of exit codes and durations",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			// 스크립트에서 사용할 수 있도록 실패가 있으면 0 이 아닌 종료 코드 (연결 정리 후 종료)
+			if failed := runExec(args); failed > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+// 실패한 인스턴스 수 반환
+func runExec(args []string) int {
+	ctx := context.Background()
+	mustAwsConfig()
+
+	command := strings.Join(args, " ")
+	targets := resolveTargetSet(ctx, viper.GetString("exec-target"), viper.GetString("exec-group"), viper.GetString("exec-name"), "명령을 실행할 인스턴스를 선택하세요:")
+	bastion := resolveBastion(ctx)
+
+	bastionClient, err := internal.DialBastion(bastion)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	defer bastionClient.Close()
+
+	keepAliveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go internal.KeepAlive(keepAliveCtx, bastionClient, internal.KeepAliveInterval)
+
+	jsonOutput := viper.GetBool("exec-json")
+	options := internal.ExecOptions{
+		User:    viper.GetString("exec-user"),
+		Sudo:    viper.GetBool("exec-sudo"),
+		Timeout: viper.GetDuration("exec-timeout"),
+	}
+	results := internal.RunRemoteCommands(ctx, bastionClient, targets, command, options, viper.GetInt("exec-concurrency"), !jsonOutput, os.Stdout)
+
+	failed := 0
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Id)
+		if result.ExitCode != 0 {
+			failed++
+		}
+	}
+
+	var actionErr error
+	if failed > 0 {
+		actionErr = fmt.Errorf("%d of %d instances failed", failed, len(results))
+	}
+	recordAudit(ctx, "exec", ids, map[string]string{
+		"command": command,
+		"sudo":    fmt.Sprintf("%t", options.Sudo),
+	}, actionErr)
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			internal.RealPanic(err)
+		}
+	} else {
+		fmt.Println()
+		internal.PrintExecSummary(results)
+		internal.LogBatchSummary("exec", command, len(results)-failed, failed)
+	}
+
+	return failed
+}
+
+func init() {
+	execCommand.Flags().StringP("target", "t", "", "ec2 instanceIds, comma separated")
+	execCommand.Flags().StringP("group", "g", "", "ec2 instance group (value of the group tag, glob allowed)")
+	execCommand.Flags().StringP("name", "n", "", "ec2 instance Name tag (glob allowed)")
+	execCommand.Flags().StringP("user", "u", "", "remote user (default ec2-user)")
+	execCommand.Flags().Bool("sudo", false, "run the command with sudo (non-interactive)")
+	execCommand.Flags().Duration("timeout", time.Minute, "per-host timeout")
+	execCommand.Flags().Int("concurrency", 20, "maximum number of hosts running the command at the same time")
+	execCommand.Flags().Bool("json", false, "print results as JSON instead of streaming output")
+	viper.BindPFlag("exec-target", execCommand.Flags().Lookup("target"))
+	viper.BindPFlag("exec-group", execCommand.Flags().Lookup("group"))
+	viper.BindPFlag("exec-name", execCommand.Flags().Lookup("name"))
+	viper.BindPFlag("exec-user", execCommand.Flags().Lookup("user"))
+	viper.BindPFlag("exec-sudo", execCommand.Flags().Lookup("sudo"))
+	viper.BindPFlag("exec-timeout", execCommand.Flags().Lookup("timeout"))
+	viper.BindPFlag("exec-concurrency", execCommand.Flags().Lookup("concurrency"))
+	viper.BindPFlag("exec-json", execCommand.Flags().Lookup("json"))
+
+	rootCmd.AddCommand(execCommand)
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	menuChangeRegion = "리전 변경"
+	menuExit         = "종료"
+	menuBack         = "뒤로"
+)
+
+type (
+	menuAction struct {
+		Name     string
+		Command  *cobra.Command
+		Settings map[string]interface{}
+	}
+
+	menuService struct {
+		Name    string
+		Actions []menuAction
+	}
+)
+
+// mcl 단독 실행 시 서비스 선택 메뉴
+func menuServices() []menuService {
+	return []menuService{
+		{Name: "EC2", Actions: []menuAction{
+			{Name: "인스턴스 조회", Command: startEc2Command},
+			{Name: "인스턴스 조회 (전체 상태)", Command: startEc2Command, Settings: map[string]interface{}{"ec2-state": "all"}},
+			{Name: "그룹 목록", Command: ec2GroupsCommand},
+			{Name: "인스턴스 시작", Command: ec2ActionCommand(internal.InstanceActionStart)},
+			{Name: "인스턴스 중지", Command: ec2ActionCommand(internal.InstanceActionStop)},
+			{Name: "인스턴스 재부팅", Command: ec2ActionCommand(internal.InstanceActionReboot)},
+			{Name: "인스턴스 종료", Command: ec2ActionCommand(internal.InstanceActionTerminate)},
+		}},
+		{Name: "SSM", Actions: []menuAction{
+			{Name: "세션 연결", Command: ssmCmd},
+		}},
+		{Name: "SSH", Actions: []menuAction{
+			{Name: "bastion 경유 셸 접속", Command: sshCommand},
+			{Name: "포트 포워딩 터널", Command: tunnelCommand},
+		}},
+		{Name: "Volume", Actions: []menuAction{
+			{Name: "사용량 확인 (Check)", Command: startVolumeCommand, Settings: map[string]interface{}{"volume-function": "check"}},
+			{Name: "볼륨 확장 (Expansion)", Command: startVolumeCommand, Settings: map[string]interface{}{"volume-function": "expand"}},
+		}},
+		{Name: "RDS", Actions: []menuAction{
+			{Name: "인스턴스 조회", Command: startRdsCommand},
+		}},
+		{Name: "ElastiCache", Actions: []menuAction{
+			{Name: "클러스터 조회", Command: startElastiCacheCommand},
+		}},
+		{Name: "S3", Actions: []menuAction{
+			{Name: "버킷 조회", Command: s3Command},
+			{Name: "객체 조회", Command: s3Command, Settings: map[string]interface{}{"s3-list-objects": true}},
+		}},
+		{Name: "CloudFront", Actions: []menuAction{
+			{Name: "배포 조회", Command: startCloudFrontCommand},
+			{Name: "무효화 생성 (/*)", Command: startCloudFrontCommand, Settings: map[string]interface{}{"cloudfront-invalidation": true}},
+		}},
+		{Name: "EKS", Actions: []menuAction{
+			{Name: "클러스터 조회 및 kubectl", Command: startEksCommand},
+		}},
+	}
+}
+
+func runServiceMenu(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	// 전역 AWS Config 사용 (세션 동안 유지)
+	awsConfig := GetGlobalAwsConfig()
+	if awsConfig == nil {
+		cmd.Help()
+		return
+	}
+
+	services := menuServices()
+	serviceOptions := make([]string, 0, len(services)+2)
+	for _, service := range services {
+		serviceOptions = append(serviceOptions, service.Name)
+	}
+	serviceOptions = append(serviceOptions, menuChangeRegion, menuExit)
+
+	for {
+		internal.LogInfo("profile: %s, region: %s", currentProfile(), GetGlobalRegion())
+
+		selected, err := internal.AskMenu("서비스를 선택하세요:", serviceOptions)
+		if err != nil {
+			if errors.Is(err, terminal.InterruptErr) {
+				return
+			}
+			internal.RealPanic(err)
+		}
+
+		switch selected {
+		case menuExit:
+			return
+		case menuChangeRegion:
+			internal.RunRecoverable(func() {
+				changeRegion(ctx)
+			})
+		default:
+			for _, service := range services {
+				if service.Name == selected {
+					runServiceActions(service)
+					break
+				}
+			}
+		}
+	}
+}
+
+// 서비스별 작업 메뉴 (작업이 끝나면 다시 작업 메뉴로 복귀)
+func runServiceActions(service menuService) {
+	options := make([]string, 0, len(service.Actions)+1)
+	for _, action := range service.Actions {
+		options = append(options, action.Name)
+	}
+	options = append(options, menuBack)
+
+	for {
+		selected, err := internal.AskMenu(service.Name+" 작업을 선택하세요:", options)
+		if err != nil || selected == menuBack {
+			return
+		}
+
+		for _, action := range service.Actions {
+			if action.Name == selected {
+				// 작업 중 에러가 나도 메뉴로 복귀
+				internal.RunRecoverable(func() {
+					runMenuAction(action)
+				})
+				break
+			}
+		}
+	}
+}
+
+// 플래그 대신 viper 설정을 임시로 바꿔 명령어 실행
+func runMenuAction(action menuAction) {
+	previous := make(map[string]interface{}, len(action.Settings))
+	for key, value := range action.Settings {
+		previous[key] = viper.Get(key)
+		viper.Set(key, value)
+	}
+	defer func() {
+		for key, value := range previous {
+			viper.Set(key, value)
+		}
+	}()
+
+	action.Command.Run(action.Command, nil)
+}
+
+func changeRegion(ctx context.Context) {
+	region, err := internal.AskRegion(ctx, *GetGlobalAwsConfig())
+	if err != nil {
+		internal.RealPanic(err)
+	}
+
+	SetGlobalRegion(region.Name)
+	os.Setenv("AWS_REGION", region.Name)
+	os.Setenv("AWS_DEFAULT_REGION", region.Name)
+	internal.LogSuccess("리전을 변경했습니다: %s", region.Name)
+}
+
+func init() {
+	rootCmd.Run = runServiceMenu
+}
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/masuldev/mcl/internal"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -30,6 +32,15 @@ var (
 	version                 string
 	credential              *Credential
 	credentialWithTemporary = fmt.Sprintf("%s_temporary", config.DefaultSharedCredentialsFilename())
+
+	// AWS 자격 증명 없이 실행 가능한 명령어
+	commandsWithoutCredential = map[string]struct{}{
+		"audit":      {},
+		"help":       {},
+		"completion": {},
+		"version":    {},
+		"update":     {},
+	}
 )
 
 func Execute(version string) {
@@ -54,6 +65,58 @@ func Execute(version string) {
 	}
 }
 
+// 실행할 명령어가 AWS 자격 증명을 필요로 하는지 확인
+func RequiresCredential(args []string) bool {
+	command, rest, err := rootCmd.Find(args)
+	if err != nil {
+		return true
+	}
+	if _, ok := commandsWithoutCredential[command.Name()]; ok {
+		return false
+	}
+
+	help, version, err := parseHelpFlags(command, rest)
+	if err != nil {
+		return true
+	}
+	return !help && !version
+}
+
+// 명령어의 플래그 정의대로 파싱해 --help, --version 여부만 확인 (-- 뒤의 원격 명령 인자는 제외)
+// 실제 플래그 값이 바뀌지 않도록 값은 버리는 복사본으로 파싱
+func parseHelpFlags(command *cobra.Command, args []string) (bool, bool, error) {
+	flags := pflag.NewFlagSet(command.Name(), pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	var help, version bool
+	add := func(f *pflag.Flag) {
+		if flags.Lookup(f.Name) != nil {
+			return
+		}
+		switch {
+		case f.Name == "help":
+			flags.BoolVarP(&help, f.Name, f.Shorthand, false, "")
+		case f.Name == "version" && command == rootCmd:
+			flags.BoolVarP(&version, f.Name, f.Shorthand, false, "")
+		default:
+			flags.AddFlag(&pflag.Flag{Name: f.Name, Shorthand: f.Shorthand, NoOptDefVal: f.NoOptDefVal, Value: discardFlagValue(f.Value.Type())})
+		}
+	}
+	command.InitDefaultHelpFlag()
+	command.Flags().VisitAll(add)
+	command.InheritedFlags().VisitAll(add)
+
+	err := flags.Parse(args)
+	return help, version, err
+}
+
+// 파싱 결과를 저장하지 않는 플래그 값
+type discardFlagValue string
+
+func (v discardFlagValue) String() string   { return "" }
+func (v discardFlagValue) Set(string) error { return nil }
+func (v discardFlagValue) Type() string     { return string(v) }
+
 // 전역 AWS Config 설정
 func SetGlobalAwsConfig(cfg aws.Config) {
 	if credential == nil {
@@ -62,6 +125,14 @@ func SetGlobalAwsConfig(cfg aws.Config) {
 	credential.awsConfig = &cfg
 }
 
+// 전역 Profile 설정
+func SetGlobalProfile(profile string) {
+	if credential == nil {
+		credential = &Credential{}
+	}
+	credential.awsProfile = profile
+}
+
 // 전역 Region 설정
 func SetGlobalRegion(region string) {
 	if credential != nil && credential.awsConfig != nil {
@@ -88,10 +159,29 @@ func GetGlobalRegion() string {
 func init() {
 	rootCmd.PersistentFlags().StringP("profile", "p", "", "profile")
 	rootCmd.PersistentFlags().StringP("region", "r", "", "region")
+	rootCmd.PersistentFlags().String("group-tag", internal.DefaultGroupTag, "tag used to group ec2 instances (env: MCL_GROUP_TAG)")
+	rootCmd.PersistentFlags().String("bastion", "", "bastion instance id or Name (env: MCL_BASTION)")
+	rootCmd.PersistentFlags().String("instance-connect", internal.InstanceConnectAuto, "ec2 instance connect for ssh features: auto (when ~/.ssh/<KeyName>.pem is missing), always, never (env: MCL_INSTANCE_CONNECT)")
+	rootCmd.PersistentFlags().String("audit-sink", "", "ship audit entries to a file path or http(s) endpoint (env: MCL_AUDIT_SINK)")
 
 	// --version 플래그 지원
 	rootCmd.Flags().BoolP("version", "v", false, "Print the version and exit")
 
 	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	viper.BindPFlag("region", rootCmd.PersistentFlags().Lookup("region"))
+	viper.BindPFlag("audit-sink", rootCmd.PersistentFlags().Lookup("audit-sink"))
+	viper.BindEnv("audit-sink", "MCL_AUDIT_SINK")
+	viper.BindPFlag("bastion", rootCmd.PersistentFlags().Lookup("bastion"))
+	viper.BindEnv("bastion", "MCL_BASTION")
+	viper.BindPFlag("group-tag", rootCmd.PersistentFlags().Lookup("group-tag"))
+	viper.BindEnv("group-tag", "MCL_GROUP_TAG")
+	viper.BindPFlag("instance-connect", rootCmd.PersistentFlags().Lookup("instance-connect"))
+	viper.BindEnv("instance-connect", "MCL_INSTANCE_CONNECT")
+
+	cobra.OnInitialize(func() {
+		internal.SetGroupTag(viper.GetString("group-tag"))
+		if err := internal.SetInstanceConnect(GetGlobalAwsConfig(), viper.GetString("instance-connect")); err != nil {
+			internal.RealPanic(err)
+		}
+	})
 }
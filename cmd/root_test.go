@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// 모든 명령어의 --help 실행 (전역 플래그와 단축키가 겹치면 cobra 가 panic)
+func TestHelpForEveryCommand(t *testing.T) {
+	var commands []*cobra.Command
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		commands = append(commands, c)
+		for _, child := range c.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	for _, c := range commands {
+		path := strings.Fields(c.CommandPath())[1:]
+		t.Run(c.CommandPath(), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("%s --help panicked: %v", c.CommandPath(), r)
+				}
+			}()
+			rootCmd.SetArgs(append(path, "--help"))
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatal(fmt.Errorf("%s --help: %w", c.CommandPath(), err))
+			}
+		})
+	}
+}
+
+func TestRequiresCredential(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"--help"}, false},
+		{[]string{"-v"}, false},
+		{[]string{"ec2", "-h"}, false},
+		{[]string{"exec", "-g", "web", "--help"}, false},
+		{[]string{"version"}, false},
+		{[]string{"ec2"}, true},
+		// -- 뒤는 원격 명령 인자
+		{[]string{"exec", "-g", "web", "--", "df", "-h"}, true},
+		{[]string{"exec", "--", "grep", "-v", "x", "/etc/hosts"}, true},
+		{[]string{"ec2", "run", "--", "ls", "-h"}, true},
+		// 값을 받는 플래그의 값은 플래그로 보지 않음
+		{[]string{"exec", "--user", "-h", "--", "ls"}, true},
+		{[]string{"exec", "-v"}, true},
+	}
+	for _, tt := range tests {
+		if got := RequiresCredential(tt.args); got != tt.want {
+			t.Errorf("RequiresCredential(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
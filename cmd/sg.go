@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	sgCommand = &cobra.Command{
+		Use:   "sg",
+		Short: "Inspect security groups",
+	}
+
+	sgAuditCommand = &cobra.Command{
+		Use:   "audit",
+		Short: "Audit security groups for exposure",
+		Long:  "Scan security groups in the region and report world-open ingress (0.0.0.0/0, ::/0) on sensitive ports, groups not attached to any network interface and rules referencing deleted groups. Each finding lists the ec2, rds and elasticache resources using the group",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			minSeverity := strings.ToUpper(strings.TrimSpace(viper.GetString("sg-audit-severity")))
+			if _, ok := internal.SGSeverityRank[minSeverity]; !ok {
+				internal.RealPanic(fmt.Errorf("invalid --severity %q (high, medium, low)", viper.GetString("sg-audit-severity")))
+			}
+
+			findings, err := internal.AuditSecurityGroups(ctx, *awsConfig)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			var filtered []internal.SGFinding
+			counts := make(map[string]int)
+			for _, finding := range findings {
+				if internal.SGSeverityRank[finding.Severity] > internal.SGSeverityRank[minSeverity] {
+					continue
+				}
+				filtered = append(filtered, finding)
+				counts[finding.Severity]++
+			}
+			if len(filtered) == 0 {
+				internal.LogSuccess("발견된 문제가 없습니다.")
+				return
+			}
+
+			internal.PrintSGFindings(filtered)
+			internal.LogInfo("high: %d, medium: %d, low: %d", counts[internal.SGSeverityHigh], counts[internal.SGSeverityMedium], counts[internal.SGSeverityLow])
+		},
+	}
+)
+
+func init() {
+	sgAuditCommand.Flags().String("severity", "low", "minimum severity to report (high, medium, low)")
+	viper.BindPFlag("sg-audit-severity", sgAuditCommand.Flags().Lookup("severity"))
+
+	sgCommand.AddCommand(sgAuditCommand)
+	rootCmd.AddCommand(sgCommand)
+}
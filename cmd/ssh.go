@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	sshCommand = &cobra.Command{
+		Use:   "ssh",
+		Short: "Open an interactive shell on an ec2 instance through the bastion",
+		Long:  "Open an interactive PTY shell on a private ec2 instance through the bastion, using ~/.ssh/<KeyName>.pem like the volume feature (EC2 Instance Connect when the pem is missing)",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			mustAwsConfig()
+
+			target := resolveSingleTarget(ctx, viper.GetString("ssh-target"), viper.GetString("ssh-group"), viper.GetString("ssh-name"), "접속할 인스턴스를 선택하세요:")
+
+			env, err := internal.ParseShellEnv(viper.GetStringSlice("ssh-env"))
+			if err != nil {
+				internal.RealPanic(err)
+			}
+			runSSHSession(ctx, target, internal.ShellOptions{
+				User:         viper.GetString("ssh-user"),
+				ForwardAgent: viper.GetBool("ssh-forward-agent"),
+				Env:          env,
+			})
+		},
+	}
+)
+
+// bastion 경유로 대상 인스턴스에 대화형 셸 세션 실행
+func runSSHSession(ctx context.Context, target *internal.Target, options internal.ShellOptions) {
+	bastion := resolveBastion(ctx)
+
+	bastionClient, err := internal.DialBastion(bastion)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	defer bastionClient.Close()
+
+	keepAliveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go internal.KeepAlive(keepAliveCtx, bastionClient, internal.KeepAliveInterval)
+
+	internal.LogInfo("SSH 세션을 시작합니다: %s (%s, %s) via %s", target.Name, target.Id, target.PrivateIp, bastion.Name)
+	err = internal.StartInteractiveShell(bastionClient, target, options)
+
+	// 마지막 명령의 종료 코드는 세션 실패로 보지 않음
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		internal.LogInfo("SSH 세션 종료 (exit code %d)", exitErr.ExitStatus())
+		return
+	}
+	if err != nil {
+		internal.RealPanic(fmt.Errorf("SSH 세션 연결 실패: %w", err))
+	}
+	internal.LogSuccess("SSH 세션 종료: %s (%s)", target.Name, target.Id)
+}
+
+// --target(ID), --group(그룹 태그), --name(Name 태그, glob)으로 실행 중인 인스턴스 하나 결정
+func resolveSingleTarget(ctx context.Context, id, group, name, message string) *internal.Target {
+	return resolveSingleTargetWithStates(ctx, []string{"running"}, id, group, name, message)
+}
+
+// resolveSingleTarget 과 같지만 지정한 상태의 인스턴스 중에서 결정
+func resolveSingleTargetWithStates(ctx context.Context, states []string, id, group, name, message string) *internal.Target {
+	awsConfig := mustAwsConfig()
+	id, group, name = strings.TrimSpace(id), strings.TrimSpace(group), strings.TrimSpace(name)
+
+	filter := runningInstanceFilter(group, name)
+	filter.States = states
+
+	if id == "" && group == "" && name == "" {
+		target, err := internal.AskTargetWithFilter(ctx, *awsConfig, filter)
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		return target
+	}
+
+	targets := findEc2Targets(ctx, filter, id)
+	if len(targets) == 0 {
+		internal.RealPanic(fmt.Errorf("no %s instance matches target=%q group=%q name=%q", strings.Join(states, "/"), id, group, name))
+	}
+	target, err := internal.AskTargetFrom(targets, message)
+	if err != nil {
+		internal.RealPanic(err)
+	}
+	return target
+}
+
+// --target(콤마 구분), --group, --name 에 해당하는 실행 중인 인스턴스 전체, 없으면 멀티 선택
+func resolveTargetSet(ctx context.Context, id, group, name, message string) []*internal.Target {
+	id, group, name = strings.TrimSpace(id), strings.TrimSpace(group), strings.TrimSpace(name)
+	filter := runningInstanceFilter(group, name)
+
+	if id == "" && group == "" && name == "" {
+		targets, err := internal.AskTargets(ctx, *mustAwsConfig(), filter, message)
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		internal.SortTargets(targets)
+		return targets
+	}
+
+	targets := findEc2Targets(ctx, filter, id)
+	if len(targets) == 0 {
+		internal.RealPanic(fmt.Errorf("no running instance matches target=%q group=%q name=%q", id, group, name))
+	}
+	return targets
+}
+
+// 그룹 태그, Name 태그(glob) 조건의 실행 중인 인스턴스 필터
+func runningInstanceFilter(group, name string) internal.InstanceFilter {
+	filter := internal.InstanceFilter{States: []string{"running"}}
+	if group != "" {
+		filter.Tags = append(filter.Tags, internal.TagFilter{Key: internal.GroupTag(), Value: group})
+	}
+	if name != "" {
+		filter.Tags = append(filter.Tags, internal.TagFilter{Key: "Name", Value: name})
+	}
+	return filter
+}
+
+// --bastion(ID 또는 Name, env: MCL_BASTION)이 없으면 선택
+func resolveBastion(ctx context.Context) *internal.Target {
+	awsConfig := mustAwsConfig()
+
+	argBastion := strings.TrimSpace(viper.GetString("bastion"))
+	if argBastion == "" {
+		bastion, err := internal.AskBastion(ctx, *awsConfig)
+		if err != nil {
+			internal.RealPanic(internal.WrapError(err))
+		}
+		return bastion
+	}
+
+	table, err := internal.FindInstance(ctx, *awsConfig)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	for _, t := range table {
+		if matched, _ := path.Match(argBastion, t.Name); t.Id == argBastion || matched {
+			return t
+		}
+	}
+	internal.RealPanic(fmt.Errorf("bastion %q not found in running instances", argBastion))
+	return nil
+}
+
+func init() {
+	sshCommand.Flags().StringP("target", "t", "", "ec2 instanceId")
+	sshCommand.Flags().StringP("group", "g", "", "ec2 instance group (value of the group tag, glob allowed)")
+	sshCommand.Flags().StringP("name", "n", "", "ec2 instance Name tag (glob allowed)")
+	sshCommand.Flags().StringP("user", "u", "", "remote user (default ec2-user)")
+	sshCommand.Flags().BoolP("forward-agent", "A", false, "forward the local ssh-agent (SSH_AUTH_SOCK) to the target")
+	sshCommand.Flags().StringArrayP("env", "e", nil, "send environment variable KEY=VALUE or KEY from the local environment (repeatable)")
+	viper.BindPFlag("ssh-target", sshCommand.Flags().Lookup("target"))
+	viper.BindPFlag("ssh-group", sshCommand.Flags().Lookup("group"))
+	viper.BindPFlag("ssh-name", sshCommand.Flags().Lookup("name"))
+	viper.BindPFlag("ssh-user", sshCommand.Flags().Lookup("user"))
+	viper.BindPFlag("ssh-forward-agent", sshCommand.Flags().Lookup("forward-agent"))
+	viper.BindPFlag("ssh-env", sshCommand.Flags().Lookup("env"))
+
+	rootCmd.AddCommand(sshCommand)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	sshConfigCommand = &cobra.Command{
+		Use:   "ssh-config",
+		Short: "Generate ~/.ssh/config host entries from the ec2 inventory",
+		Long:  "Render one Host block per running ec2 instance (HostName=private ip, IdentityFile=~/.ssh/<KeyName>.pem, ProxyJump=bastion) into ~/.ssh/config.d/mcl, so plain ssh/scp/rsync/VS Code Remote work without mcl. Re-running updates the profile/region section in place",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			filter := runningInstanceFilter(viper.GetString("ssh-config-group"), viper.GetString("ssh-config-name"))
+			targets, err := internal.FindInstanceWithFilter(ctx, *awsConfig, filter)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			if len(targets) == 0 {
+				internal.RealPanic(fmt.Errorf("no running instance found"))
+			}
+			bastion := resolveBastion(ctx)
+
+			instances := make([]*internal.Target, 0, len(targets)+1)
+			instances = append(instances, bastion)
+			for _, target := range targets {
+				instances = append(instances, target)
+			}
+
+			users, err := internal.DetectSSHUsers(ctx, *awsConfig, instances)
+			if err != nil {
+				internal.LogWarning("AMI 기반 사용자 추정 실패, 기본 사용자를 사용합니다: %v", err)
+			}
+
+			hosts := internal.BuildSSHHosts(bastion, instances[1:], users, viper.GetString("ssh-config-prefix"), viper.GetString("ssh-config-user"))
+			content := internal.RenderSSHHosts(hosts)
+
+			if viper.GetBool("ssh-config-dry-run") {
+				fmt.Print(content)
+				return
+			}
+
+			path := internal.SSHConfigPath()
+			section := fmt.Sprintf("%s/%s", currentProfile(), awsConfig.Region)
+			if err := internal.WriteSSHConfigSection(path, section, content); err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("%d개 Host 를 %s 에 기록했습니다 (section: %s)", len(hosts), path, section)
+
+			ensureSSHConfigInclude()
+			internal.LogInfo("예: ssh %s", hosts[len(hosts)-1].Alias)
+		},
+	}
+)
+
+// ~/.ssh/config 에 Include 가 없으면 추가 여부 확인
+func ensureSSHConfigInclude() {
+	included, err := internal.HasSSHConfigInclude()
+	if err != nil {
+		internal.LogWarning("~/.ssh/config 확인 실패: %v", err)
+		return
+	}
+	if included {
+		return
+	}
+
+	var add bool
+	prompt := &survey.Confirm{Message: "~/.ssh/config 맨 앞에 'Include config.d/mcl' 을 추가하시겠습니까?", Default: true}
+	if err := survey.AskOne(prompt, &add); err != nil {
+		internal.RealPanic(err)
+	}
+	if !add {
+		internal.LogWarning("~/.ssh/config 에 'Include config.d/mcl' 을 직접 추가해야 적용됩니다")
+		return
+	}
+	if err := internal.AddSSHConfigInclude(); err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	internal.LogSuccess("~/.ssh/config 에 Include 를 추가했습니다")
+}
+
+func init() {
+	sshConfigCommand.Flags().StringP("group", "g", "", "only instances in this group (value of the group tag, glob allowed)")
+	sshConfigCommand.Flags().StringP("name", "n", "", "only instances whose Name tag matches (glob allowed)")
+	sshConfigCommand.Flags().String("prefix", "", "prefix for generated host aliases (e.g. prod-)")
+	sshConfigCommand.Flags().StringP("user", "u", "", "remote user for every host (default: detected from the AMI, else ec2-user)")
+	sshConfigCommand.Flags().Bool("dry-run", false, "print the generated entries instead of writing them")
+	viper.BindPFlag("ssh-config-group", sshConfigCommand.Flags().Lookup("group"))
+	viper.BindPFlag("ssh-config-name", sshConfigCommand.Flags().Lookup("name"))
+	viper.BindPFlag("ssh-config-prefix", sshConfigCommand.Flags().Lookup("prefix"))
+	viper.BindPFlag("ssh-config-user", sshConfigCommand.Flags().Lookup("user"))
+	viper.BindPFlag("ssh-config-dry-run", sshConfigCommand.Flags().Lookup("dry-run"))
+
+	rootCmd.AddCommand(sshConfigCommand)
+}
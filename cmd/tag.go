@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	tagCommand = &cobra.Command{
+		Use:   "tag",
+		Short: "Add, remove, copy and list tags on ec2, ebs, rds and elasticache",
+		Long:  "Bulk tag editor for ec2 instances, ebs volumes, rds instances and elasticache clusters. Resources are picked interactively or selected with --id/--tag, and every change is previewed as a diff before it is applied",
+	}
+
+	tagAddCommand = &cobra.Command{
+		Use:   "add KEY=VALUE [KEY=VALUE...]",
+		Short: "Add or overwrite tags",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			tags, err := internal.ParseTags(args)
+			if err != nil {
+				internal.RealPanic(err)
+			}
+
+			resources := resolveTaggedResources(ctx, "태그를 추가할 리소스를 선택하세요:")
+			resources = append(resources, tagAttachedVolumes(ctx, resources)...)
+
+			changes := make([]internal.TagChange, 0, len(resources))
+			for _, resource := range resources {
+				changes = append(changes, internal.DiffTags(resource, tags, nil))
+			}
+			applyTagChanges(ctx, "add", changes, map[string]string{"tags": strings.Join(args, ",")})
+		},
+	}
+
+	tagRemoveCommand = &cobra.Command{
+		Use:   "remove KEY [KEY...]",
+		Short: "Remove tags",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			resources := resolveTaggedResources(ctx, "태그를 삭제할 리소스를 선택하세요:")
+			resources = append(resources, tagAttachedVolumes(ctx, resources)...)
+
+			changes := make([]internal.TagChange, 0, len(resources))
+			for _, resource := range resources {
+				changes = append(changes, internal.DiffTags(resource, nil, args))
+			}
+			applyTagChanges(ctx, "remove", changes, map[string]string{"keys": strings.Join(args, ",")})
+		},
+	}
+
+	tagCopyCommand = &cobra.Command{
+		Use:   "copy",
+		Short: "Copy tags from one resource to others, or from instances to their volumes",
+		Long:  "With --from, copy the tags of one resource to the selected resources. Without --from (--type ec2), propagate each selected instance's tags to its attached ebs volumes. Name is skipped unless listed in --keys",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+			keys := viper.GetStringSlice("tag-keys")
+			from := strings.TrimSpace(viper.GetString("tag-from"))
+
+			var changes []internal.TagChange
+			params := map[string]string{"keys": strings.Join(keys, ",")}
+			if from != "" {
+				fromType := viper.GetString("tag-from-type")
+				if fromType == "" {
+					fromType = tagResourceType()
+				}
+				if err := internal.ValidateResourceType(fromType); err != nil {
+					internal.RealPanic(err)
+				}
+				sources, err := internal.FindTaggedResources(ctx, *awsConfig, fromType, nil, []string{from})
+				if err != nil {
+					internal.RealPanic(internal.WrapError(err))
+				}
+				if len(sources) != 1 {
+					internal.RealPanic(fmt.Errorf("source %s %q not found", fromType, from))
+				}
+				tags := copiedTags(sources[0].Tags, keys)
+				params["from"] = sources[0].Id
+
+				targets := resolveTaggedResources(ctx, "태그를 복사할 대상 리소스를 선택하세요:")
+				for _, resource := range append(targets, tagAttachedVolumes(ctx, targets)...) {
+					if resource.Id != sources[0].Id {
+						changes = append(changes, internal.DiffTags(resource, tags, nil))
+					}
+				}
+			} else {
+				if tagResourceType() != internal.ResourceEC2 {
+					internal.RealPanic(fmt.Errorf("--from is required unless propagating ec2 instance tags to volumes (--type ec2)"))
+				}
+				instances := resolveTaggedResources(ctx, "볼륨으로 태그를 전파할 인스턴스를 선택하세요:")
+				changes = propagationChanges(ctx, instances, keys)
+				params["propagate"] = "volumes"
+			}
+			applyTagChanges(ctx, "copy", changes, params)
+		},
+	}
+
+	tagListCommand = &cobra.Command{
+		Use:   "list",
+		Short: "List resources with their tags",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			resources, err := internal.FindTaggedResources(ctx, *mustAwsConfig(), tagResourceType(), tagFiltersFromFlags(), tagIdsFromFlags())
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			if len(resources) == 0 {
+				internal.LogWarning("조건에 맞는 리소스가 없습니다.")
+				return
+			}
+			internal.PrintTaggedResources(resources)
+		},
+	}
+)
+
+func tagResourceType() string {
+	resourceType := strings.ToLower(strings.TrimSpace(viper.GetString("tag-type")))
+	if err := internal.ValidateResourceType(resourceType); err != nil {
+		internal.RealPanic(err)
+	}
+	return resourceType
+}
+
+func tagFiltersFromFlags() []internal.TagFilter {
+	filters, err := internal.ParseTagFilters(viper.GetStringSlice("tag-tag"))
+	if err != nil {
+		internal.RealPanic(err)
+	}
+	return filters
+}
+
+func tagIdsFromFlags() []string {
+	var ids []string
+	for _, id := range strings.Split(viper.GetString("tag-id"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// --id, --tag 가 있으면 일치하는 전체, 없으면 목록에서 선택
+func resolveTaggedResources(ctx context.Context, message string) []*internal.TaggedResource {
+	filters, ids := tagFiltersFromFlags(), tagIdsFromFlags()
+	resources, err := internal.FindTaggedResources(ctx, *mustAwsConfig(), tagResourceType(), filters, ids)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	if len(filters) > 0 || len(ids) > 0 {
+		if len(resources) == 0 {
+			internal.RealPanic(fmt.Errorf("no %s matches the given --id/--tag", tagResourceType()))
+		}
+		return resources
+	}
+
+	selected, err := internal.AskTaggedResources(resources, message)
+	if err != nil {
+		internal.RealPanic(err)
+	}
+	return selected
+}
+
+// --volumes 가 있으면 선택한 인스턴스의 EBS 볼륨도 같은 변경 대상에 포함
+func tagAttachedVolumes(ctx context.Context, resources []*internal.TaggedResource) []*internal.TaggedResource {
+	if !viper.GetBool("tag-volumes") {
+		return nil
+	}
+	if tagResourceType() != internal.ResourceEC2 {
+		internal.RealPanic(fmt.Errorf("--volumes is only valid with --type ec2"))
+	}
+
+	ids := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		ids = append(ids, resource.Id)
+	}
+	volumes, err := internal.FindAttachedVolumes(ctx, *mustAwsConfig(), ids)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+	return volumes
+}
+
+// 인스턴스 태그를 연결된 볼륨으로 전파하는 변경 목록
+func propagationChanges(ctx context.Context, instances []*internal.TaggedResource, keys []string) []internal.TagChange {
+	byId := make(map[string]*internal.TaggedResource, len(instances))
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		byId[instance.Id] = instance
+		ids = append(ids, instance.Id)
+	}
+
+	volumes, err := internal.FindAttachedVolumes(ctx, *mustAwsConfig(), ids)
+	if err != nil {
+		internal.RealPanic(internal.WrapError(err))
+	}
+
+	changes := make([]internal.TagChange, 0, len(volumes))
+	for _, volume := range volumes {
+		if instance, ok := byId[volume.AttachedTo]; ok {
+			changes = append(changes, internal.DiffTags(volume, copiedTags(instance.Tags, keys), nil))
+		}
+	}
+	return changes
+}
+
+// --keys 가 없으면 Name 을 제외한 전체 태그
+func copiedTags(tags map[string]string, keys []string) map[string]string {
+	selected := internal.SelectTags(tags, keys)
+	if len(keys) == 0 {
+		delete(selected, "Name")
+	}
+	return selected
+}
+
+// 변경 미리보기 → 확인 → 적용 → 결과 요약, 감사 로그 기록
+func applyTagChanges(ctx context.Context, operation string, changes []internal.TagChange, params map[string]string) {
+	pending := make([]internal.TagChange, 0, len(changes))
+	for _, change := range changes {
+		if !change.Empty() {
+			pending = append(pending, change)
+			internal.PrintTagChange("tag", change)
+		}
+	}
+	if len(pending) == 0 {
+		internal.LogInfo("변경할 태그가 없습니다.")
+		return
+	}
+
+	if !viper.GetBool("tag-yes") {
+		var proceed bool
+		prompt := &survey.Confirm{Message: fmt.Sprintf("%d개 리소스의 태그를 변경하시겠습니까?", len(pending)), Default: false}
+		if err := survey.AskOne(prompt, &proceed); err != nil {
+			internal.RealPanic(err)
+		}
+		if !proceed {
+			internal.LogWarning("작업이 취소되었습니다.")
+			return
+		}
+	}
+
+	results := internal.ApplyTagChanges(ctx, *mustAwsConfig(), pending)
+
+	var failed int
+	var firstErr error
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Change.Resource.Id)
+		if result.Err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+			internal.LogError("%s: %v", result.Change.Resource.Label(), result.Err)
+		}
+	}
+	params["type"] = tagResourceType()
+	recordAudit(ctx, "tag "+operation, ids, params, firstErr)
+	internal.LogBatchSummary("tag", operation, len(results)-failed, failed)
+}
+
+func init() {
+	tagCommand.PersistentFlags().String("type", internal.ResourceEC2, "resource type: ec2, volume, rds or elasticache")
+	tagCommand.PersistentFlags().String("id", "", "resource ids (comma separated; rds/elasticache identifiers)")
+	tagCommand.PersistentFlags().StringArray("tag", nil, "select resources by tag key=value (repeatable, glob values with * and ?)")
+	tagCommand.PersistentFlags().BoolP("yes", "y", false, "apply without confirmation")
+	viper.BindPFlag("tag-type", tagCommand.PersistentFlags().Lookup("type"))
+	viper.BindPFlag("tag-id", tagCommand.PersistentFlags().Lookup("id"))
+	viper.BindPFlag("tag-tag", tagCommand.PersistentFlags().Lookup("tag"))
+	viper.BindPFlag("tag-yes", tagCommand.PersistentFlags().Lookup("yes"))
+
+	tagCommand.PersistentFlags().Bool("volumes", false, "also apply to the ebs volumes attached to the selected instances (--type ec2)")
+	viper.BindPFlag("tag-volumes", tagCommand.PersistentFlags().Lookup("volumes"))
+
+	tagCopyCommand.Flags().String("from", "", "source resource id (default: propagate instance tags to attached volumes)")
+	tagCopyCommand.Flags().String("from-type", "", "source resource type (default: --type)")
+	tagCopyCommand.Flags().StringSlice("keys", nil, "only copy these tag keys (comma separated)")
+	viper.BindPFlag("tag-from", tagCopyCommand.Flags().Lookup("from"))
+	viper.BindPFlag("tag-from-type", tagCopyCommand.Flags().Lookup("from-type"))
+	viper.BindPFlag("tag-keys", tagCopyCommand.Flags().Lookup("keys"))
+
+	tagCommand.AddCommand(tagAddCommand, tagRemoveCommand, tagCopyCommand, tagListCommand)
+	rootCmd.AddCommand(tagCommand)
+}
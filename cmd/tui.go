@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	tuiCommand = &cobra.Command{
+		Use:   "tui",
+		Short: "Full-screen terminal dashboard for AWS services",
+		Long:  "Full-screen terminal dashboard with EC2, RDS, ElastiCache, S3, CloudFront and EKS tabs",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+
+			// 전역 AWS Config 사용
+			awsConfig := GetGlobalAwsConfig()
+			if awsConfig == nil {
+				internal.RealPanic(fmt.Errorf("AWS config not initialized"))
+			}
+
+			dashboard := internal.NewDashboard(ctx, *awsConfig, func(command string, targets []string, params map[string]string, err error) {
+				recordAudit(ctx, command, targets, params, err)
+			})
+			if err := dashboard.Run(viper.GetDuration("tui-refresh")); err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+		},
+	}
+)
+
+func init() {
+	tuiCommand.Flags().Duration("refresh", 30*time.Second, "refresh interval of the current tab (0 to disable)")
+	viper.BindPFlag("tui-refresh", tuiCommand.Flags().Lookup("refresh"))
+
+	rootCmd.AddCommand(tuiCommand)
+}
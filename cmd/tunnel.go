@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+const (
+	tunnelRefreshInterval = time.Second
+)
+
+var (
+	tunnelCommand = &cobra.Command{
+		Use:   "tunnel",
+		Short: "Forward local ports to private endpoints through the bastion",
+		Long:  "Forward local ports to RDS, ElastiCache, EC2 or any host:port through the bastion SSH connection until Ctrl-C",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			awsConfig := mustAwsConfig()
+
+			forwards, names := tunnelForwards(ctx, *awsConfig)
+			bastion := resolveBastion(ctx)
+
+			bastionClient, err := internal.DialBastion(bastion)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			defer bastionClient.Close()
+
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go internal.KeepAlive(ctx, bastionClient, internal.KeepAliveInterval)
+
+			tunnels := make([]*internal.Tunnel, 0, len(forwards))
+			for i, forward := range forwards {
+				tunnel, err := internal.OpenTunnel(bastionClient, names[i], *forward)
+				if err != nil {
+					for _, opened := range tunnels {
+						opened.Close()
+					}
+					internal.RealPanic(fmt.Errorf("cannot listen on local port for %s: %w", forward.Remote, err))
+				}
+				tunnels = append(tunnels, tunnel)
+				go func() {
+					if err := tunnel.Serve(ctx); err != nil {
+						internal.PrintError(err)
+					}
+				}()
+			}
+
+			internal.LogSuccess("%d개 터널을 열었습니다 (bastion: %s). Ctrl-C 로 종료합니다.", len(tunnels), bastion.Name)
+			renderTunnels(ctx, tunnels)
+			internal.LogInfo("터널을 종료했습니다.")
+		},
+	}
+)
+
+// -L 값 또는 대화형 선택으로 포워딩 목록 결정
+func tunnelForwards(ctx context.Context, awsConfig aws.Config) ([]*internal.Port, []string) {
+	var forwards []*internal.Port
+	var names []string
+
+	for _, value := range viper.GetStringSlice("tunnel-forward") {
+		forward, err := internal.ParseForward(value)
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		forwards = append(forwards, forward)
+		names = append(names, forward.Remote)
+	}
+	if len(forwards) > 0 {
+		return forwards, names
+	}
+
+	for {
+		name, forward, err := internal.AskTunnelDestination(ctx, awsConfig)
+		if err != nil {
+			internal.RealPanic(err)
+		}
+		forwards = append(forwards, forward)
+		names = append(names, name)
+
+		var more bool
+		if err := survey.AskOne(&survey.Confirm{Message: "터널을 더 추가하시겠습니까?", Default: false}, &more); err != nil {
+			internal.RealPanic(err)
+		}
+		if !more {
+			return forwards, names
+		}
+	}
+}
+
+// 종료될 때까지 터널별 연결 수와 전송량 갱신 출력 (터미널이 아니면 시작, 종료 시에만 출력)
+func renderTunnels(ctx context.Context, tunnels []*internal.Tunnel) {
+	draw := func(redraw bool) {
+		if redraw {
+			fmt.Printf("\033[%dA", len(tunnels))
+		}
+		for _, tunnel := range tunnels {
+			if redraw {
+				fmt.Print("\033[2K")
+			}
+			internal.PrintTunnel("tunnel", tunnel)
+		}
+	}
+
+	draw(false)
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		<-ctx.Done()
+		draw(false)
+		return
+	}
+
+	ticker := time.NewTicker(tunnelRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			draw(true)
+		case <-ctx.Done():
+			draw(true)
+			return
+		}
+	}
+}
+
+func init() {
+	tunnelCommand.Flags().StringArrayP("forward", "L", nil, "forward [localPort:]host:port, IPv6 as [addr]:port (repeatable); without it a picker is shown")
+	viper.BindPFlag("tunnel-forward", tunnelCommand.Flags().Lookup("forward"))
+
+	rootCmd.AddCommand(tunnelCommand)
+}
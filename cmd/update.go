@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/masuldev/mcl/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	versionCommand = &cobra.Command{
+		Use:   "version",
+		Short: "Print the version of mcl",
+		Long:  "Print the version of mcl, optionally checking for a newer release",
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Printf("mcl version %s\n", currentVersion())
+
+			if !viper.GetBool("version-check") {
+				return
+			}
+
+			release, err := internal.FetchLatestRelease(context.Background(), releaseURL("version-release-url"))
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			if internal.IsNewerVersion(rootCmd.Version, release.Version()) {
+				internal.LogWarning("새 버전이 있습니다: %s -> %s (%s)", currentVersion(), release.Version(), release.HTMLURL)
+				internal.LogInfo("`mcl update` 로 업데이트할 수 있습니다.")
+				return
+			}
+			internal.LogSuccess("최신 버전입니다: %s", currentVersion())
+		},
+	}
+
+	updateCommand = &cobra.Command{
+		Use:   "update",
+		Short: "Update mcl to the latest release",
+		Long:  "Download the latest release for this OS/arch, verify it against the published checksums and replace the running binary",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+
+			release, err := internal.FetchLatestRelease(ctx, releaseURL("update-release-url"))
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+
+			if !internal.IsNewerVersion(rootCmd.Version, release.Version()) && !viper.GetBool("update-force") {
+				internal.LogSuccess("이미 최신 버전입니다: %s", currentVersion())
+				return
+			}
+
+			internal.LogInfo("%s 다운로드 중... (%s -> %s)", internal.ReleaseArchiveName(release.Version()), currentVersion(), release.Version())
+			binary, err := internal.DownloadRelease(ctx, release)
+			if err != nil {
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("checksum 검증 완료")
+
+			path, err := internal.ReplaceExecutable(binary)
+			if err != nil {
+				if strings.Contains(err.Error(), "permission denied") {
+					internal.LogWarning("Homebrew로 설치한 경우 `brew upgrade mcl`을, 그 외에는 쓰기 권한을 확인하세요.")
+				}
+				internal.RealPanic(internal.WrapError(err))
+			}
+			internal.LogSuccess("mcl %s 로 업데이트했습니다: %s", release.Version(), path)
+		},
+	}
+)
+
+func currentVersion() string {
+	if rootCmd.Version == "" {
+		return "dev"
+	}
+	return rootCmd.Version
+}
+
+// 릴리즈 조회 URL (플래그 > MCL_RELEASE_URL > GitHub releases API)
+func releaseURL(key string) string {
+	if url := strings.TrimSpace(viper.GetString(key)); url != "" {
+		return url
+	}
+	return internal.DefaultReleaseURL
+}
+
+func init() {
+	versionCommand.Flags().Bool("check", false, "check whether a newer release is available")
+	versionCommand.Flags().String("release-url", "", "release api url (env: MCL_RELEASE_URL)")
+	viper.BindPFlag("version-check", versionCommand.Flags().Lookup("check"))
+	viper.BindPFlag("version-release-url", versionCommand.Flags().Lookup("release-url"))
+	viper.BindEnv("version-release-url", "MCL_RELEASE_URL")
+
+	updateCommand.Flags().Bool("force", false, "reinstall even if the current version is the latest")
+	updateCommand.Flags().String("release-url", "", "release api url (env: MCL_RELEASE_URL)")
+	viper.BindPFlag("update-force", updateCommand.Flags().Lookup("force"))
+	viper.BindPFlag("update-release-url", updateCommand.Flags().Lookup("release-url"))
+	viper.BindEnv("update-release-url", "MCL_RELEASE_URL")
+
+	rootCmd.AddCommand(versionCommand)
+	rootCmd.AddCommand(updateCommand)
+}
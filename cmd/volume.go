@@ -46,17 +46,14 @@ var (
 				ThresholdPercentage = 80
 			}
 
-			bastion, err := internal.AskBastion(ctx, *credential.awsConfig)
-			if err != nil {
-				internal.RealPanic(internal.WrapError(err))
-			}
+			bastion := resolveBastion(ctx)
 
 			instances, err := internal.FindInstance(ctx, *credential.awsConfig)
 			if err != nil {
 				internal.RealPanic(internal.WrapError(err))
 			}
 
-			bastionClient, err := internal.ConnectionBastion(bastion.PublicIp, bastion.KeyName)
+			bastionClient, err := internal.ConnectionBastion(bastion)
 			if err != nil {
 				internal.RealPanic(internal.WrapError(err))
 			}
@@ -90,6 +87,7 @@ var (
 
 					if doExpand {
 						volumes, err := internal.ExpandAndModifyVolumes(ctx, *credential.awsConfig, instances, instancesWithHighUsage, IncrementPercentage, bastionClient)
+						recordVolumeExpansion(ctx, instancesWithHighUsage, volumes, IncrementPercentage, ThresholdPercentage, err)
 						if err != nil {
 							internal.RealPanic(err)
 						}
@@ -109,6 +107,7 @@ var (
 					}
 
 					volumes, err := internal.ExpandAndModifyVolumes(ctx, *credential.awsConfig, instances, targets, IncrementPercentage, bastionClient)
+					recordVolumeExpansion(ctx, targets, volumes, IncrementPercentage, ThresholdPercentage, err)
 					if err != nil {
 						internal.RealPanic(internal.WrapError(err))
 					}
@@ -138,6 +137,25 @@ var (
 	}
 )
 
+// 볼륨 확장(ModifyVolume + growpart) 감사 로그 기록
+func recordVolumeExpansion(ctx context.Context, targets []*internal.Target, volumes []internal.VolumeInstanceMapping, increment, threshold int, err error) {
+	instanceIds := make([]string, 0, len(targets))
+	for _, target := range targets {
+		instanceIds = append(instanceIds, target.Id)
+	}
+
+	expanded := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		expanded = append(expanded, fmt.Sprintf("%s:%dGB->%dGB", volume.Volume.Id, volume.Volume.Size, volume.Volume.NewSize))
+	}
+
+	recordAudit(ctx, "volume expand", instanceIds, map[string]string{
+		"increment": fmt.Sprintf("%d%%", increment),
+		"threshold": fmt.Sprintf("%d%%", threshold),
+		"expanded":  strings.Join(expanded, ","),
+	}, err)
+}
+
 func init() {
 	startVolumeCommand.Flags().StringP("function", "f", "", "function name")
 	startVolumeCommand.Flags().StringP("threshold", "t", "", "volume threshold percentage")
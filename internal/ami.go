@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	AmiActionKeep   = "keep"
+	AmiActionDelete = "delete"
+	AmiActionInUse  = "in use"
+
+	amiNameTimeFormat = "20060102-150405"
+)
+
+type (
+	AmiOptions struct {
+		Name        string
+		Description string
+		NoReboot    bool
+		Tags        map[string]string
+	}
+
+	AmiImage struct {
+		Id           string
+		Name         string
+		State        string
+		CreationDate time.Time
+		Snapshots    []string
+		Tags         map[string]string
+	}
+
+	// 정리 계획 한 줄 (그룹 내 최신 N 개는 keep, 나머지는 delete, 사용 중인 AMI 는 유지)
+	AmiPrunePlan struct {
+		Group  string
+		Image  *AmiImage
+		Action string
+	}
+)
+
+// 인스턴스 이름과 시각으로 기본 AMI 이름 생성 (AMI 이름 허용 문자 외에는 '-')
+func DefaultAmiName(target *Target) string {
+	name := target.Name
+	if name == "" {
+		name = target.Id
+	}
+	name = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("()[]./-'@_ ", r) {
+			return r
+		}
+		return '-'
+	}, name)
+	return fmt.Sprintf("%s-%s", name, time.Now().Format(amiNameTimeFormat))
+}
+
+// 인스턴스에서 AMI 생성 (이미지와 스냅샷 모두 태그 지정)
+func CreateImage(ctx context.Context, cfg aws.Config, target *Target, options AmiOptions) (string, error) {
+	tags := []types.Tag{
+		{Key: aws.String("Name"), Value: aws.String(options.Name)},
+		{Key: aws.String("SourceInstanceId"), Value: aws.String(target.Id)},
+	}
+	for key, value := range options.Tags {
+		if key == "Name" || key == "SourceInstanceId" {
+			continue
+		}
+		tags = append(tags, types.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+
+	input := &ec2.CreateImageInput{
+		InstanceId: aws.String(target.Id),
+		Name:       aws.String(options.Name),
+		NoReboot:   aws.Bool(options.NoReboot),
+		TagSpecifications: []types.TagSpecification{
+			{ResourceType: types.ResourceTypeImage, Tags: tags},
+			{ResourceType: types.ResourceTypeSnapshot, Tags: tags},
+		},
+	}
+	if options.Description != "" {
+		input.Description = aws.String(options.Description)
+	}
+
+	output, err := ec2.NewFromConfig(cfg).CreateImage(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.ImageId), nil
+}
+
+func WaitImageAvailable(ctx context.Context, cfg aws.Config, imageId string, timeout time.Duration) error {
+	waiter := ec2.NewImageAvailableWaiter(ec2.NewFromConfig(cfg))
+	return waiter.Wait(ctx, &ec2.DescribeImagesInput{ImageIds: []string{imageId}}, timeout)
+}
+
+// 계정 소유 AMI 중 이름 패턴(glob), 태그 조건에 맞는 것
+func FindOwnedImages(ctx context.Context, cfg aws.Config, namePatterns []string, tags []TagFilter) ([]*AmiImage, error) {
+	filters := tagFilters(tags)
+	if len(namePatterns) > 0 {
+		filters = append(filters, types.Filter{Name: aws.String("name"), Values: namePatterns})
+	}
+	input := &ec2.DescribeImagesInput{Owners: []string{"self"}}
+	if len(filters) > 0 {
+		input.Filters = filters
+	}
+
+	var images []*AmiImage
+	paginator := ec2.NewDescribeImagesPaginator(ec2.NewFromConfig(cfg), input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range output.Images {
+			images = append(images, newAmiImage(image))
+		}
+	}
+	return images, nil
+}
+
+func newAmiImage(image types.Image) *AmiImage {
+	created, _ := time.Parse(time.RFC3339, aws.ToString(image.CreationDate))
+	tags := make(map[string]string, len(image.Tags))
+	for _, tag := range image.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	var snapshots []string
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+			snapshots = append(snapshots, aws.ToString(mapping.Ebs.SnapshotId))
+		}
+	}
+	return &AmiImage{
+		Id:           aws.ToString(image.ImageId),
+		Name:         aws.ToString(image.Name),
+		State:        string(image.State),
+		CreationDate: created,
+		Snapshots:    snapshots,
+		Tags:         tags,
+	}
+}
+
+// 종료되지 않은 인스턴스가 사용 중인 AMI ID
+func FindImagesInUse(ctx context.Context, cfg aws.Config) (map[string]struct{}, error) {
+	table, err := FindInstanceWithFilter(ctx, cfg, InstanceFilter{States: InstanceStates[:len(InstanceStates)-1]})
+	if err != nil {
+		return nil, err
+	}
+	inUse := make(map[string]struct{})
+	for _, target := range table {
+		inUse[target.ImageId] = struct{}{}
+	}
+	return inUse, nil
+}
+
+// 그룹(태그 값 또는 일치한 이름 패턴)별로 최신 keep 개를 남기고 나머지를 삭제 대상으로 표시
+func PlanImagePrune(images []*AmiImage, namePatterns []string, groupTag string, keep int, inUse map[string]struct{}) []AmiPrunePlan {
+	groups := make(map[string][]*AmiImage)
+	for _, image := range images {
+		group, ok := amiGroup(image, namePatterns, groupTag)
+		if ok {
+			groups[group] = append(groups[group], image)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var plan []AmiPrunePlan
+	for _, name := range names {
+		group := groups[name]
+		sort.Slice(group, func(i, j int) bool { return group[i].CreationDate.After(group[j].CreationDate) })
+		for i, image := range group {
+			action := AmiActionKeep
+			if i >= keep {
+				action = AmiActionDelete
+				if _, ok := inUse[image.Id]; ok {
+					action = AmiActionInUse
+				}
+			}
+			plan = append(plan, AmiPrunePlan{Group: name, Image: image, Action: action})
+		}
+	}
+	return plan
+}
+
+func amiGroup(image *AmiImage, namePatterns []string, groupTag string) (string, bool) {
+	if groupTag != "" {
+		value, ok := image.Tags[groupTag]
+		return fmt.Sprintf("%s=%s", groupTag, value), ok
+	}
+	for _, pattern := range namePatterns {
+		if matched, _ := path.Match(pattern, image.Name); matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// AMI 등록 해제 후 연결된 스냅샷 삭제 (다른 AMI 가 쓰는 스냅샷은 삭제 실패로 남음)
+func DeregisterImage(ctx context.Context, cfg aws.Config, image *AmiImage) error {
+	client := ec2.NewFromConfig(cfg)
+	if _, err := client.DeregisterImage(ctx, &ec2.DeregisterImageInput{ImageId: aws.String(image.Id)}); err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, snapshot := range image.Snapshots {
+		if _, err := client.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{SnapshotId: aws.String(snapshot)}); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", snapshot, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("deregistered, but snapshot deletion failed: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func PrintAmiPrunePlan(plan []AmiPrunePlan) {
+	rows := make([][]string, 0, len(plan))
+	for _, p := range plan {
+		rows = append(rows, []string{p.Group, p.Image.Id, p.Image.Name, p.Image.CreationDate.Local().Format("2006-01-02 15:04"), p.Image.State, strings.Join(p.Image.Snapshots, ","), p.Action})
+	}
+	LogTable([]string{"GROUP", "AMI", "NAME", "CREATED", "STATE", "SNAPSHOTS", "ACTION"}, rows)
+}
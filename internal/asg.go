@@ -0,0 +1,386 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	astypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+const (
+	// ASG 가 시작한 인스턴스에 자동으로 붙는 태그
+	AsgGroupTag = "aws:autoscaling:groupName"
+)
+
+var (
+	AsgProcesses = []string{
+		"Launch", "Terminate", "AddToLoadBalancer", "AlarmNotification", "AZRebalance",
+		"HealthCheck", "InstanceRefresh", "ReplaceUnhealthy", "ScheduledActions",
+	}
+
+	asgRefreshDone = map[astypes.InstanceRefreshStatus]struct{}{
+		astypes.InstanceRefreshStatusSuccessful:         {},
+		astypes.InstanceRefreshStatusFailed:             {},
+		astypes.InstanceRefreshStatusCancelled:          {},
+		astypes.InstanceRefreshStatusRollbackSuccessful: {},
+		astypes.InstanceRefreshStatusRollbackFailed:     {},
+	}
+)
+
+type (
+	AutoScalingGroup struct {
+		Name      string              `json:"name"`
+		Desired   int32               `json:"desired"`
+		Min       int32               `json:"min"`
+		Max       int32               `json:"max"`
+		InService int                 `json:"inService"`
+		Suspended []string            `json:"suspendedProcesses"`
+		Instances []AutoScalingMember `json:"instances"`
+	}
+
+	AutoScalingMember struct {
+		Id               string `json:"id"`
+		LifecycleState   string `json:"lifecycleState"`
+		HealthStatus     string `json:"healthStatus"`
+		AvailabilityZone string `json:"availabilityZone"`
+		InstanceType     string `json:"instanceType"`
+	}
+
+	InstanceRefreshOptions struct {
+		MinHealthyPercentage int32
+		InstanceWarmup       int32
+		SkipMatching         bool
+	}
+
+	InstanceRefresh struct {
+		Id                 string
+		Status             string
+		StatusReason       string
+		PercentageComplete int32
+		InstancesToUpdate  int32
+		StartTime, EndTime time.Time
+	}
+)
+
+func FindAutoScalingGroups(ctx context.Context, cfg aws.Config) ([]*AutoScalingGroup, error) {
+	var groups []*AutoScalingGroup
+	paginator := autoscaling.NewDescribeAutoScalingGroupsPaginator(autoscaling.NewFromConfig(cfg), &autoscaling.DescribeAutoScalingGroupsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range output.AutoScalingGroups {
+			groups = append(groups, newAutoScalingGroup(g))
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+func FindAutoScalingGroup(ctx context.Context, cfg aws.Config, name string) (*AutoScalingGroup, error) {
+	output, err := autoscaling.NewFromConfig(cfg).DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("auto scaling group %s not found", name)
+	}
+	return newAutoScalingGroup(output.AutoScalingGroups[0]), nil
+}
+
+func newAutoScalingGroup(g astypes.AutoScalingGroup) *AutoScalingGroup {
+	group := &AutoScalingGroup{
+		Name:    aws.ToString(g.AutoScalingGroupName),
+		Desired: aws.ToInt32(g.DesiredCapacity),
+		Min:     aws.ToInt32(g.MinSize),
+		Max:     aws.ToInt32(g.MaxSize),
+	}
+	for _, p := range g.SuspendedProcesses {
+		group.Suspended = append(group.Suspended, aws.ToString(p.ProcessName))
+	}
+	sort.Strings(group.Suspended)
+	for _, instance := range g.Instances {
+		if instance.LifecycleState == astypes.LifecycleStateInService {
+			group.InService++
+		}
+		group.Instances = append(group.Instances, AutoScalingMember{
+			Id:               aws.ToString(instance.InstanceId),
+			LifecycleState:   string(instance.LifecycleState),
+			HealthStatus:     aws.ToString(instance.HealthStatus),
+			AvailabilityZone: aws.ToString(instance.AvailabilityZone),
+			InstanceType:     aws.ToString(instance.InstanceType),
+		})
+	}
+	sort.Slice(group.Instances, func(i, j int) bool { return group.Instances[i].Id < group.Instances[j].Id })
+	return group
+}
+
+// ASG 소속 인스턴스 조회용 필터 (FindInstanceWithFilter 와 함께 사용)
+func AsgInstanceFilter(name string, states []string) InstanceFilter {
+	return InstanceFilter{States: states, Tags: []TagFilter{{Key: AsgGroupTag, Value: name}}}
+}
+
+func AskAutoScalingGroup(groups []*AutoScalingGroup, message string) (*AutoScalingGroup, error) {
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("no auto scaling group found")
+	}
+	if len(groups) == 1 {
+		return groups[0], nil
+	}
+
+	options := make([]string, 0, len(groups))
+	groupMap := make(map[string]*AutoScalingGroup, len(groups))
+	for _, g := range groups {
+		option := fmt.Sprintf("%s (desired %d, min %d, max %d, in service %d)", g.Name, g.Desired, g.Min, g.Max, g.InService)
+		options = append(options, option)
+		groupMap[option] = g
+	}
+
+	var selected string
+	prompt := &survey.Select{Message: message, Options: options}
+	if err := survey.AskOne(prompt, &selected, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Format = "green+hb"
+	}), survey.WithPageSize(20)); err != nil {
+		return nil, err
+	}
+	return groupMap[selected], nil
+}
+
+func SetDesiredCapacity(ctx context.Context, cfg aws.Config, group *AutoScalingGroup, desired int32, honorCooldown bool) error {
+	if desired < group.Min || desired > group.Max {
+		return fmt.Errorf("desired capacity %d is outside min %d / max %d", desired, group.Min, group.Max)
+	}
+	_, err := autoscaling.NewFromConfig(cfg).SetDesiredCapacity(ctx, &autoscaling.SetDesiredCapacityInput{
+		AutoScalingGroupName: aws.String(group.Name),
+		DesiredCapacity:      aws.Int32(desired),
+		HonorCooldown:        aws.Bool(honorCooldown),
+	})
+	return err
+}
+
+// 프로세스 이름 검증 (대소문자 무시, 비어 있으면 전체)
+func ParseAsgProcesses(values []string) ([]string, error) {
+	var processes []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			matched := ""
+			for _, process := range AsgProcesses {
+				if strings.EqualFold(process, name) {
+					matched = process
+				}
+			}
+			if matched == "" {
+				return nil, fmt.Errorf("unknown process %q (%s)", name, strings.Join(AsgProcesses, ", "))
+			}
+			processes = append(processes, matched)
+		}
+	}
+	return processes, nil
+}
+
+// processes 가 비어 있으면 전체 프로세스
+func SuspendProcesses(ctx context.Context, cfg aws.Config, name string, processes []string) error {
+	_, err := autoscaling.NewFromConfig(cfg).SuspendProcesses(ctx, &autoscaling.SuspendProcessesInput{
+		AutoScalingGroupName: aws.String(name),
+		ScalingProcesses:     processes,
+	})
+	return err
+}
+
+func ResumeProcesses(ctx context.Context, cfg aws.Config, name string, processes []string) error {
+	_, err := autoscaling.NewFromConfig(cfg).ResumeProcesses(ctx, &autoscaling.ResumeProcessesInput{
+		AutoScalingGroupName: aws.String(name),
+		ScalingProcesses:     processes,
+	})
+	return err
+}
+
+func StartInstanceRefresh(ctx context.Context, cfg aws.Config, name string, options InstanceRefreshOptions) (string, error) {
+	preferences := &astypes.RefreshPreferences{
+		MinHealthyPercentage: aws.Int32(options.MinHealthyPercentage),
+		SkipMatching:         aws.Bool(options.SkipMatching),
+	}
+	if options.InstanceWarmup > 0 {
+		preferences.InstanceWarmup = aws.Int32(options.InstanceWarmup)
+	}
+	output, err := autoscaling.NewFromConfig(cfg).StartInstanceRefresh(ctx, &autoscaling.StartInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(name),
+		Preferences:          preferences,
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.InstanceRefreshId), nil
+}
+
+func CancelInstanceRefresh(ctx context.Context, cfg aws.Config, name string) (string, error) {
+	output, err := autoscaling.NewFromConfig(cfg).CancelInstanceRefresh(ctx, &autoscaling.CancelInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(name),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.InstanceRefreshId), nil
+}
+
+// 최근 인스턴스 새로 고침 (id 가 비어 있으면 가장 최근 것)
+func DescribeInstanceRefresh(ctx context.Context, cfg aws.Config, name, id string) (*InstanceRefresh, error) {
+	input := &autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: aws.String(name),
+		MaxRecords:           aws.Int32(1),
+	}
+	if id != "" {
+		input.InstanceRefreshIds = []string{id}
+	}
+	output, err := autoscaling.NewFromConfig(cfg).DescribeInstanceRefreshes(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(output.InstanceRefreshes) == 0 {
+		return nil, fmt.Errorf("no instance refresh found for %s", name)
+	}
+
+	r := output.InstanceRefreshes[0]
+	return &InstanceRefresh{
+		Id:                 aws.ToString(r.InstanceRefreshId),
+		Status:             string(r.Status),
+		StatusReason:       aws.ToString(r.StatusReason),
+		PercentageComplete: aws.ToInt32(r.PercentageComplete),
+		InstancesToUpdate:  aws.ToInt32(r.InstancesToUpdate),
+		StartTime:          aws.ToTime(r.StartTime),
+		EndTime:            aws.ToTime(r.EndTime),
+	}, nil
+}
+
+func (r *InstanceRefresh) Done() bool {
+	_, ok := asgRefreshDone[astypes.InstanceRefreshStatus(r.Status)]
+	return ok
+}
+
+// Baking 은 교체 후 대기 중 (아직 롤백될 수 있음)
+func (r *InstanceRefresh) Succeeded() bool {
+	return r.Status == string(astypes.InstanceRefreshStatusSuccessful)
+}
+
+// 완료될 때까지 interval 마다 진행률 출력
+func WatchInstanceRefresh(ctx context.Context, cfg aws.Config, name, id string, interval time.Duration) (*InstanceRefresh, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := ""
+	for {
+		refresh, err := DescribeInstanceRefresh(ctx, cfg, name, id)
+		if err != nil {
+			return nil, err
+		}
+		if line := refresh.Summary(); line != last {
+			LogInfo("%s: %s", name, line)
+			last = line
+		}
+		if refresh.Done() {
+			return refresh, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return refresh, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *InstanceRefresh) Summary() string {
+	summary := fmt.Sprintf("refresh %s %s, %d%% complete, %d instances to update", r.Id, r.Status, r.PercentageComplete, r.InstancesToUpdate)
+	if r.StatusReason != "" {
+		summary += " (" + r.StatusReason + ")"
+	}
+	return summary
+}
+
+// decrement 이면 standby 로 전환한 만큼 desired capacity 를 줄여 대체 인스턴스를 띄우지 않음
+func EnterStandby(ctx context.Context, cfg aws.Config, name string, ids []string, decrement bool) error {
+	_, err := autoscaling.NewFromConfig(cfg).EnterStandby(ctx, &autoscaling.EnterStandbyInput{
+		AutoScalingGroupName:           aws.String(name),
+		InstanceIds:                    ids,
+		ShouldDecrementDesiredCapacity: aws.Bool(decrement),
+	})
+	return err
+}
+
+func ExitStandby(ctx context.Context, cfg aws.Config, name string, ids []string) error {
+	_, err := autoscaling.NewFromConfig(cfg).ExitStandby(ctx, &autoscaling.ExitStandbyInput{
+		AutoScalingGroupName: aws.String(name),
+		InstanceIds:          ids,
+	})
+	return err
+}
+
+func AutoScalingGroupTable(groups []*AutoScalingGroup) ([]string, [][]string) {
+	headers := []string{"NAME", "DESIRED", "MIN", "MAX", "IN SERVICE", "INSTANCES", "SUSPENDED"}
+	rows := make([][]string, 0, len(groups))
+	for _, g := range groups {
+		suspended := strings.Join(g.Suspended, ",")
+		if suspended == "" {
+			suspended = "-"
+		}
+		rows = append(rows, []string{
+			g.Name, strconv.Itoa(int(g.Desired)), strconv.Itoa(int(g.Min)), strconv.Itoa(int(g.Max)),
+			strconv.Itoa(g.InService), strconv.Itoa(len(g.Instances)), suspended,
+		})
+	}
+	return headers, rows
+}
+
+// ASG 멤버 정보와 EC2 인스턴스(이름, IP) 를 함께 출력
+func PrintAutoScalingMembers(group *AutoScalingGroup, instances map[string]*Target) {
+	rows := make([][]string, 0, len(group.Instances))
+	for _, member := range group.Instances {
+		name, privateIp := "-", "-"
+		if target, ok := instances[member.Id]; ok {
+			name, privateIp = target.Name, target.PrivateIp
+		}
+		rows = append(rows, []string{member.Id, name, privateIp, member.LifecycleState, member.HealthStatus, member.AvailabilityZone, member.InstanceType})
+	}
+	LogTable([]string{"ID", "NAME", "PRIVATE IP", "LIFECYCLE", "HEALTH", "AZ", "TYPE"}, rows)
+}
+
+func AskAutoScalingMembers(members []AutoScalingMember, message string) ([]string, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no instance to select")
+	}
+
+	options := make([]string, 0, len(members))
+	idMap := make(map[string]string, len(members))
+	for _, member := range members {
+		option := fmt.Sprintf("%s - %s, %s, %s", member.Id, member.LifecycleState, member.HealthStatus, member.AvailabilityZone)
+		options = append(options, option)
+		idMap[option] = member.Id
+	}
+
+	var selected []string
+	prompt := &survey.MultiSelect{Message: message, Options: options}
+	if err := survey.AskOne(prompt, &selected, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Format = "green+hb"
+	}), survey.WithPageSize(20), survey.WithValidator(survey.MinItems(1))); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(selected))
+	for _, option := range selected {
+		ids = append(ids, idMap[option])
+	}
+	return ids, nil
+}
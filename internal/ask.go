@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -70,7 +71,12 @@ func AskTime() (*Time, error) {
 }
 
 func AskTarget(ctx context.Context, cfg aws.Config) (*Target, error) {
-	table, err := FindInstance(ctx, cfg)
+	return AskTargetWithFilter(ctx, cfg, InstanceFilter{States: []string{"running"}})
+}
+
+// 상태, 태그 필터에 맞는 인스턴스 중 하나 선택
+func AskTargetWithFilter(ctx context.Context, cfg aws.Config, filter InstanceFilter) (*Target, error) {
+	table, err := FindInstanceWithFilter(ctx, cfg, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +84,7 @@ func AskTarget(ctx context.Context, cfg aws.Config) (*Target, error) {
 	displayMap := make(map[string]*Target, len(table))
 	options := make([]string, 0, len(table))
 	for _, target := range table {
-		option := fmt.Sprintf("%s (%s)", target.Name, target.Id)
+		option := target.Summary()
 		options = append(options, option)
 		displayMap[option] = target
 	}
@@ -102,10 +108,93 @@ func AskTarget(ctx context.Context, cfg aws.Config) (*Target, error) {
 	return displayMap[selectKey], nil
 }
 
+// 여러 인스턴스 선택 (필터에 맞는 인스턴스만 표시)
+func AskTargets(ctx context.Context, cfg aws.Config, filter InstanceFilter, message string) ([]*Target, error) {
+	table, err := FindInstanceWithFilter(ctx, cfg, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	displayMap := make(map[string]*Target, len(table))
+	options := make([]string, 0, len(table))
+	for _, target := range table {
+		option := target.Summary()
+		options = append(options, option)
+		displayMap[option] = target
+	}
+	sort.Strings(options)
+	if len(options) == 0 {
+		return nil, fmt.Errorf("not found ec2 instance in state: %s", strings.Join(filter.States, ", "))
+	}
+
+	prompt := &survey.MultiSelect{
+		Message: message,
+		Options: options,
+	}
+
+	var selectKeys []string
+	if err := survey.AskOne(prompt, &selectKeys, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Format = "green+hb"
+	}), survey.WithPageSize(20), survey.WithValidator(survey.MinItems(1))); err != nil {
+		return nil, err
+	}
+
+	targets := make([]*Target, 0, len(selectKeys))
+	for _, key := range selectKeys {
+		targets = append(targets, displayMap[key])
+	}
+	return targets, nil
+}
+
+// 이름을 직접 입력해야 통과하는 확인 (종료 등 되돌릴 수 없는 작업용)
+func AskTypedConfirm(message, expected string) (bool, error) {
+	var typed string
+	prompt := &survey.Input{
+		Message: fmt.Sprintf("%s '%s' 을(를) 입력하세요:", message, expected),
+	}
+	if err := survey.AskOne(prompt, &typed); err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(typed) == expected, nil
+}
+
 //func AskRdsTarsget(ctx context.Context, cfg aws.Config) (*RdsTarget, error) {
 //	table, err := Find
 //}
 
+// 이미 조회한 인스턴스 목록에서 하나 선택 (하나뿐이면 바로 반환)
+func AskTargetFrom(targets []*Target, message string) (*Target, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("not found ec2 instance")
+	}
+	if len(targets) == 1 {
+		return targets[0], nil
+	}
+
+	displayMap := make(map[string]*Target, len(targets))
+	options := make([]string, 0, len(targets))
+	for _, target := range targets {
+		option := target.Summary()
+		options = append(options, option)
+		displayMap[option] = target
+	}
+	sort.Strings(options)
+
+	prompt := &survey.Select{
+		Message: message,
+		Options: options,
+	}
+
+	selectKey := ""
+	if err := survey.AskOne(prompt, &selectKey, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Format = "green+hb"
+	}), survey.WithPageSize(20)); err != nil {
+		return nil, err
+	}
+
+	return displayMap[selectKey], nil
+}
+
 func AskBastion(ctx context.Context, cfg aws.Config) (*Target, error) {
 	table, err := FindInstance(ctx, cfg)
 	if err != nil {
@@ -151,7 +240,7 @@ func AskRegion(ctx context.Context, cfg aws.Config) (*Region, error) {
 		regions = make([]string, len(defaultAwsRegions))
 		copy(regions, defaultAwsRegions)
 	} else {
-		regions = make([]string, len(output.Regions))
+		regions = make([]string, 0, len(output.Regions))
 		for _, region := range output.Regions {
 			regions = append(regions, aws.ToString(region.RegionName))
 		}
@@ -174,6 +263,22 @@ func AskRegion(ctx context.Context, cfg aws.Config) (*Region, error) {
 	return &Region{Name: region}, nil
 }
 
+func AskMenu(message string, options []string) (string, error) {
+	var selected string
+	prompt := &survey.Select{
+		Message: message,
+		Options: options,
+	}
+
+	if err := survey.AskOne(prompt, &selected, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Format = "green+hb"
+	}), survey.WithPageSize(20)); err != nil {
+		return "", err
+	}
+
+	return selected, nil
+}
+
 func AskVolume(ctx context.Context, cfg aws.Config) (*Function, error) {
 	functions := []string{"Check", "Expansion"}
 
@@ -196,6 +301,16 @@ func PrintEc2(cmd, region, name, id, publicIp, privateIp string) {
 	LogEC2Instance(cmd, region, name, id, publicIp, privateIp)
 }
 
+// 인스턴스 기본 정보와 상세 속성 출력
+func PrintEc2Target(cmd, region string, target *Target) {
+	LogEC2Instance(cmd, region, target.Name, target.Id, target.PublicIp, target.PrivateIp)
+	LogAttributes(target.Attributes())
+}
+
+func PrintInstanceGroup(cmd, groupTag, group string, count int) {
+	LogInstanceGroup(cmd, groupTag, group, count)
+}
+
 func PrintVolumeCheck(cmd, instanceId, instanceName, instanceIp string, usage int) {
 	LogVolumeUsage(cmd, instanceId, instanceName, instanceIp, usage)
 }
@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+type (
+	AuditEntry struct {
+		Timestamp  time.Time         `json:"timestamp"`
+		Identity   string            `json:"identity"`
+		Profile    string            `json:"profile"`
+		Region     string            `json:"region"`
+		Command    string            `json:"command"`
+		Targets    []string          `json:"targets"`
+		Parameters map[string]string `json:"parameters,omitempty"`
+		Outcome    string            `json:"outcome"`
+		Error      string            `json:"error,omitempty"`
+	}
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+
+	auditFileName    = "audit.jsonl"
+	auditSinkTimeout = 5 * time.Second
+)
+
+var (
+	auditMutex sync.Mutex
+
+	// 프로세스 동안 자격 증명이 바뀌지 않으므로 호출자 ARN은 한 번만 조회
+	callerArnMutex sync.Mutex
+	callerArn      string
+)
+
+// 감사 로그 디렉토리 (~/.local/state/mcl, XDG_STATE_HOME 우선)
+func AuditLogDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "mcl")
+	}
+	return filepath.Join(FindHomeFolder(), ".local", "state", "mcl")
+}
+
+// 감사 로그 파일 경로
+func AuditLogPath() string {
+	return filepath.Join(AuditLogDir(), auditFileName)
+}
+
+// STS로 호출자 ARN 조회
+func GetCallerIdentityArn(ctx context.Context, cfg aws.Config) (string, error) {
+	callerArnMutex.Lock()
+	defer callerArnMutex.Unlock()
+
+	if callerArn != "" {
+		return callerArn, nil
+	}
+
+	output, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	callerArn = aws.ToString(output.Arn)
+	return callerArn, nil
+}
+
+// 감사 로그 기록 (append-only), sink가 지정된 경우 함께 전송
+func WriteAuditEntry(entry AuditEntry, sink string) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+
+	if err := os.MkdirAll(AuditLogDir(), 0700); err != nil {
+		return err
+	}
+	if err := appendAuditLine(AuditLogPath(), line); err != nil {
+		return err
+	}
+
+	if sink = strings.TrimSpace(sink); sink != "" {
+		if err := shipAuditEntry(sink, line); err != nil {
+			return fmt.Errorf("failed to ship audit entry to %s: %w", sink, err)
+		}
+	}
+	return nil
+}
+
+func appendAuditLine(path string, line []byte) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(line)
+	return err
+}
+
+// 감사 로그 전송 (http(s):// 는 POST, 그 외는 파일 경로로 간주)
+func shipAuditEntry(sink string, line []byte) error {
+	if strings.HasPrefix(sink, "http://") || strings.HasPrefix(sink, "https://") {
+		client := &http.Client{Timeout: auditSinkTimeout}
+		resp, err := client.Post(sink, "application/json", bytes.NewReader(line))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status: %s", resp.Status)
+		}
+		return nil
+	}
+
+	path := strings.TrimPrefix(sink, "file://")
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return appendAuditLine(path, line)
+}
+
+// 감사 로그 조회 (since 이후, action이 command에 포함된 항목)
+func ReadAuditEntries(since time.Time, action string) ([]AuditEntry, error) {
+	file, err := os.Open(AuditLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// 손상된 라인은 건너뛰기
+			continue
+		}
+		if !since.IsZero() && entry.Timestamp.Before(since) {
+			continue
+		}
+		if action != "" && !strings.Contains(entry.Command, action) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func PrintAuditEntry(cmd string, entry AuditEntry) {
+	keys := make([]string, 0, len(entry.Parameters))
+	for key := range entry.Parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", key, entry.Parameters[key]))
+	}
+
+	outcome := entry.Outcome
+	if entry.Error != "" {
+		outcome = fmt.Sprintf("%s (%s)", entry.Outcome, entry.Error)
+	}
+
+	LogAuditEntry(cmd, entry.Timestamp.Local().Format("2006-01-02 15:04:05"), entry.Identity, entry.Profile,
+		entry.Region, entry.Command, strings.Join(entry.Targets, ","), strings.Join(params, " "), outcome)
+}
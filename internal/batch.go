@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	BatchResult struct {
+		Target   *Target
+		Output   string
+		Err      error
+		Duration time.Duration
+	}
+
+	BatchFunc func(ctx context.Context, target *Target) (string, error)
+)
+
+const (
+	DefaultBatchConcurrency = 10
+)
+
+// 여러 인스턴스에 동시 실행 개수를 제한해 작업 실행 (결과는 대상 순서대로 반환)
+func RunBatch(ctx context.Context, targets []*Target, concurrency int, f BatchFunc) []BatchResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make([]BatchResult, len(targets))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target *Target) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			started := time.Now()
+			output, err := f(ctx, target)
+			results[i] = BatchResult{Target: target, Output: output, Err: err, Duration: time.Since(started)}
+		}(i, target)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// 이름, ID 순으로 정렬 (map 순회 순서와 무관하게 항상 같은 순서)
+func SortTargets(targets []*Target) {
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Name != targets[j].Name {
+			return targets[i].Name < targets[j].Name
+		}
+		return targets[i].Id < targets[j].Id
+	})
+}
+
+func PrintBatchResult(cmd string, result BatchResult) {
+	status := ActionStatusSuccess
+	message := result.Output
+	if result.Err != nil {
+		status = ActionStatusFailed
+		message = result.Err.Error()
+	}
+	LogBatchResult(cmd, result.Target.Id, result.Target.Name, status, result.Duration.Round(time.Millisecond).String(), message)
+}
+
+// 인스턴스별 결과와 성공/실패 요약 출력, 실패 개수 반환
+func PrintBatchReport(cmd, operation string, results []BatchResult) int {
+	failed := 0
+	for _, result := range results {
+		PrintBatchResult(cmd, result)
+		if result.Err != nil {
+			failed++
+		}
+	}
+	LogBatchSummary(cmd, operation, len(results)-failed, failed)
+	return failed
+}
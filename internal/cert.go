@@ -0,0 +1,390 @@
+package internal
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	DefaultPrincipalTag = "SSH-Principals"
+	certIdentityName    = "mcl_ed25519"
+	// 서버와 시계가 조금 어긋나도 바로 사용할 수 있도록 시작 시각을 앞당김
+	certClockSkew = time.Minute
+)
+
+var (
+	// OpenSSH 기본 사용자 인증서 권한
+	defaultCertExtensions = map[string]string{
+		"permit-X11-forwarding":   "",
+		"permit-agent-forwarding": "",
+		"permit-port-forwarding":  "",
+		"permit-pty":              "",
+		"permit-user-rc":          "",
+	}
+
+	userCertificate = struct {
+		sync.Mutex
+		loaded bool
+		cert   *ssh.Certificate
+		signer ssh.Signer
+	}{}
+)
+
+type (
+	CertOptions struct {
+		KeyId      string
+		Principals []string
+		Duration   time.Duration
+	}
+
+	// KMS 비대칭 키(RSA, ECDSA)로 서명하는 CA (개인키는 KMS 밖으로 나오지 않음)
+	kmsSigner struct {
+		client    *kms.Client
+		keyId     string
+		publicKey crypto.PublicKey
+		sshKey    ssh.PublicKey
+	}
+)
+
+// 인증서에 사용할 사용자 키 (~/.ssh/mcl_ed25519)
+func CertIdentityPath() string {
+	return filepath.Join(FindHomeFolder(), ".ssh", certIdentityName)
+}
+
+// OpenSSH 규칙대로 <identity>-cert.pub
+func CertificatePath(identity string) string {
+	return identity + "-cert.pub"
+}
+
+// 사용자 키를 읽고, 없으면 ed25519 키를 새로 만들어 저장
+func LoadOrCreateIdentity(path string) (ssh.PublicKey, bool, error) {
+	key, err := os.ReadFile(path)
+	if err == nil {
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+		return signer.PublicKey(), false, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, err
+	}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, false, err
+	}
+	sshKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, false, err
+	}
+	block, err := marshalOpenSSHEd25519(privateKey, sshKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, false, err
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		return nil, false, err
+	}
+	if err := os.WriteFile(path+".pub", ssh.MarshalAuthorizedKey(sshKey), 0644); err != nil {
+		return nil, false, err
+	}
+	return sshKey, true, nil
+}
+
+// OpenSSH(openssh-key-v1) 형식의 암호 없는 ed25519 개인키 (ssh, ssh-add 에서도 사용 가능)
+func marshalOpenSSHEd25519(privateKey ed25519.PrivateKey, publicKey ssh.PublicKey) (*pem.Block, error) {
+	var check [4]byte
+	if _, err := rand.Read(check[:]); err != nil {
+		return nil, err
+	}
+
+	body := ssh.Marshal(struct {
+		Check1, Check2 uint32
+		KeyType        string
+		Public         []byte
+		Private        []byte
+		Comment        string
+	}{
+		Check1:  binary.BigEndian.Uint32(check[:]),
+		Check2:  binary.BigEndian.Uint32(check[:]),
+		KeyType: ssh.KeyAlgoED25519,
+		Public:  privateKey.Public().(ed25519.PublicKey),
+		Private: privateKey,
+		Comment: "mcl",
+	})
+	for i := byte(1); len(body)%8 != 0; i++ {
+		body = append(body, i)
+	}
+
+	key := ssh.Marshal(struct {
+		CipherName   string
+		KdfName      string
+		KdfOptions   string
+		NumKeys      uint32
+		PublicKey    []byte
+		PrivateBlock []byte
+	}{
+		CipherName:   "none",
+		KdfName:      "none",
+		NumKeys:      1,
+		PublicKey:    publicKey.Marshal(),
+		PrivateBlock: body,
+	})
+	return &pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: append([]byte("openssh-key-v1\x00"), key...)}, nil
+}
+
+// 파일 CA 키 (암호가 걸려 있으면 입력 받음)
+func LoadCASigner(path string) (ssh.Signer, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		var passphrase string
+		if err := survey.AskOne(&survey.Password{Message: fmt.Sprintf("Passphrase for %s:", path)}, &passphrase); err != nil {
+			return nil, err
+		}
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// KMS 키 ID, ARN 또는 alias/<name> 으로 CA 서명자 생성
+func NewKMSSigner(ctx context.Context, cfg aws.Config, keyId string) (ssh.Signer, error) {
+	client := kms.NewFromConfig(cfg)
+	output, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyId)})
+	if err != nil {
+		return nil, err
+	}
+	if output.KeyUsage != kmstypes.KeyUsageTypeSignVerify {
+		return nil, fmt.Errorf("kms key %s is not a SIGN_VERIFY key", keyId)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(output.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	sshKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("kms key %s: %w", keyId, err)
+	}
+	return &kmsSigner{client: client, keyId: keyId, publicKey: publicKey, sshKey: sshKey}, nil
+}
+
+func (s *kmsSigner) PublicKey() ssh.PublicKey {
+	return s.sshKey
+}
+
+func (s *kmsSigner) Sign(rand io.Reader, data []byte) (*ssh.Signature, error) {
+	return s.SignWithAlgorithm(rand, data, "")
+}
+
+// KMS 는 SHA-1 서명을 지원하지 않으므로 RSA 는 rsa-sha2-256/512 만 사용
+func (s *kmsSigner) SignWithAlgorithm(_ io.Reader, data []byte, algorithm string) (*ssh.Signature, error) {
+	var hash crypto.Hash
+	var signingAlgorithm kmstypes.SigningAlgorithmSpec
+	format := s.sshKey.Type()
+
+	switch key := s.publicKey.(type) {
+	case *rsa.PublicKey:
+		switch algorithm {
+		case ssh.KeyAlgoRSASHA256:
+			hash, signingAlgorithm = crypto.SHA256, kmstypes.SigningAlgorithmSpecRsassaPkcs1V15Sha256
+		case "", ssh.KeyAlgoRSASHA512:
+			hash, signingAlgorithm, algorithm = crypto.SHA512, kmstypes.SigningAlgorithmSpecRsassaPkcs1V15Sha512, ssh.KeyAlgoRSASHA512
+		default:
+			return nil, fmt.Errorf("kms signer: unsupported algorithm %q", algorithm)
+		}
+		format = algorithm
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			hash, signingAlgorithm = crypto.SHA256, kmstypes.SigningAlgorithmSpecEcdsaSha256
+		case elliptic.P384():
+			hash, signingAlgorithm = crypto.SHA384, kmstypes.SigningAlgorithmSpecEcdsaSha384
+		case elliptic.P521():
+			hash, signingAlgorithm = crypto.SHA512, kmstypes.SigningAlgorithmSpecEcdsaSha512
+		default:
+			return nil, fmt.Errorf("kms signer: unsupported curve %s", key.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("kms signer: unsupported key type %T", key)
+	}
+
+	digest := hash.New()
+	digest.Write(data)
+
+	ctx, cancel := context.WithTimeout(context.Background(), sshDialTimeout)
+	defer cancel()
+	output, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyId),
+		Message:          digest.Sum(nil),
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: signingAlgorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blob := output.Signature
+	if _, ok := s.publicKey.(*ecdsa.PublicKey); ok {
+		// KMS 는 ASN.1 DER, SSH 는 mpint(r) || mpint(s)
+		var sig struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(output.Signature, &sig); err != nil {
+			return nil, err
+		}
+		blob = ssh.Marshal(sig)
+	}
+	return &ssh.Signature{Format: format, Blob: blob}, nil
+}
+
+// 사용자 공개키에 CA 로 서명한 사용자 인증서 발급
+func SignUserCertificate(ca ssh.Signer, publicKey ssh.PublicKey, options CertOptions) (*ssh.Certificate, error) {
+	if len(options.Principals) == 0 {
+		return nil, fmt.Errorf("certificate needs at least one principal")
+	}
+
+	var serial [8]byte
+	if _, err := rand.Read(serial[:]); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	extensions := make(map[string]string, len(defaultCertExtensions))
+	for k, v := range defaultCertExtensions {
+		extensions[k] = v
+	}
+	cert := &ssh.Certificate{
+		Key:             publicKey,
+		Serial:          binary.BigEndian.Uint64(serial[:]),
+		CertType:        ssh.UserCert,
+		KeyId:           options.KeyId,
+		ValidPrincipals: options.Principals,
+		ValidAfter:      uint64(now.Add(-certClockSkew).Unix()),
+		ValidBefore:     uint64(now.Add(options.Duration).Unix()),
+		Permissions:     ssh.Permissions{Extensions: extensions},
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func WriteCertificate(path string, cert *ssh.Certificate) error {
+	return os.WriteFile(path, ssh.MarshalAuthorizedKey(cert), 0644)
+}
+
+// 인스턴스 태그(예: SSH-Principals=ops,ec2-user)에서 principal 수집, 없으면 fallback
+func PrincipalsForTargets(targets []*Target, tagKey string, fallback ...string) []string {
+	set := make(map[string]struct{})
+	for _, target := range targets {
+		for _, principal := range strings.FieldsFunc(target.Tags[tagKey], func(r rune) bool { return r == ',' || r == ' ' }) {
+			set[principal] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		for _, principal := range fallback {
+			if principal != "" {
+				set[principal] = struct{}{}
+			}
+		}
+	}
+
+	principals := make([]string, 0, len(set))
+	for principal := range set {
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+	return principals
+}
+
+// ~/.ssh/mcl_ed25519-cert.pub 가 유효하면 SSH 인증에 사용할 서명자, 아니면 nil
+func userCertificateSigner() ssh.Signer {
+	userCertificate.Lock()
+	defer userCertificate.Unlock()
+
+	if !userCertificate.loaded {
+		userCertificate.loaded = true
+		userCertificate.cert, userCertificate.signer = loadUserCertificate(CertIdentityPath())
+	}
+	if userCertificate.cert == nil || time.Now().Unix() >= int64(userCertificate.cert.ValidBefore) {
+		return nil
+	}
+	return userCertificate.signer
+}
+
+func loadUserCertificate(identity string) (*ssh.Certificate, ssh.Signer) {
+	certBytes, err := os.ReadFile(CertificatePath(identity))
+	if err != nil {
+		return nil, nil
+	}
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(certBytes)
+	if err != nil {
+		return nil, nil
+	}
+	cert, ok := publicKey.(*ssh.Certificate)
+	if !ok {
+		return nil, nil
+	}
+
+	key, err := os.ReadFile(identity)
+	if err != nil {
+		return nil, nil
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, nil
+	}
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, nil
+	}
+	return cert, certSigner
+}
+
+func PrintCertificate(cmd, path string, cert *ssh.Certificate) {
+	LogSuccess("[%s] 인증서를 발급했습니다: %s", cmd, path)
+	LogAttributes([][2]string{
+		{"Key ID", cert.KeyId},
+		{"Serial", fmt.Sprintf("%d", cert.Serial)},
+		{"Principals", strings.Join(cert.ValidPrincipals, ", ")},
+		{"CA", ssh.FingerprintSHA256(cert.SignatureKey)},
+		{"Valid", fmt.Sprintf("%s ~ %s", time.Unix(int64(cert.ValidAfter), 0).Format(time.RFC3339), time.Unix(int64(cert.ValidBefore), 0).Format(time.RFC3339))},
+	})
+}
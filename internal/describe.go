@@ -0,0 +1,366 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+)
+
+const (
+	// 이전 출력과 이어 붙일 때 비교할 길이
+	consoleOverlap = 256
+)
+
+type (
+	InstanceDetail struct {
+		Target         *Target
+		Volumes        []VolumeDetail
+		Interfaces     []InterfaceDetail
+		SecurityGroups []SecurityGroupDetail
+		Roles          []string
+		SystemStatus   string
+		InstanceStatus string
+		Events         []string
+		// 조회 중 실패한 항목 (권한 부족 등, 나머지는 계속 출력)
+		Warnings []string
+	}
+
+	VolumeDetail struct {
+		Id                  string
+		Device              string
+		Size                int32
+		Type                string
+		Iops                int32
+		Throughput          int32
+		Encrypted           bool
+		State               string
+		DeleteOnTermination bool
+	}
+
+	InterfaceDetail struct {
+		Id              string
+		PrivateIps      []string
+		PublicIp        string
+		SubnetId        string
+		SecurityGroups  []string
+		SourceDestCheck bool
+		Description     string
+	}
+
+	SecurityGroupDetail struct {
+		Id       string
+		Name     string
+		Inbound  []SecurityGroupRule
+		Outbound []SecurityGroupRule
+	}
+
+	SecurityGroupRule struct {
+		Protocol    string
+		Ports       string
+		Peer        string
+		Description string
+	}
+)
+
+// 인스턴스에 연결된 볼륨, ENI, 보안 그룹 규칙, IAM 역할, 상태 검사 조회
+func DescribeInstanceDetail(ctx context.Context, cfg aws.Config, target *Target) *InstanceDetail {
+	client := ec2.NewFromConfig(cfg)
+	detail := &InstanceDetail{Target: target}
+	attachmentFilter := []types.Filter{{Name: aws.String("attachment.instance-id"), Values: []string{target.Id}}}
+
+	volumes, err := client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{Filters: attachmentFilter})
+	if err != nil {
+		detail.Warnings = append(detail.Warnings, fmt.Sprintf("volumes: %v", err))
+	} else {
+		for _, volume := range volumes.Volumes {
+			v := VolumeDetail{
+				Id:         aws.ToString(volume.VolumeId),
+				Size:       aws.ToInt32(volume.Size),
+				Type:       string(volume.VolumeType),
+				Iops:       aws.ToInt32(volume.Iops),
+				Throughput: aws.ToInt32(volume.Throughput),
+				Encrypted:  aws.ToBool(volume.Encrypted),
+				State:      string(volume.State),
+			}
+			for _, attachment := range volume.Attachments {
+				if aws.ToString(attachment.InstanceId) == target.Id {
+					v.Device = aws.ToString(attachment.Device)
+					v.DeleteOnTermination = aws.ToBool(attachment.DeleteOnTermination)
+				}
+			}
+			detail.Volumes = append(detail.Volumes, v)
+		}
+		sort.Slice(detail.Volumes, func(i, j int) bool { return detail.Volumes[i].Device < detail.Volumes[j].Device })
+	}
+
+	groupIds := make(map[string]struct{})
+	interfaces, err := client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{Filters: attachmentFilter})
+	if err != nil {
+		detail.Warnings = append(detail.Warnings, fmt.Sprintf("network interfaces: %v", err))
+	} else {
+		for _, eni := range interfaces.NetworkInterfaces {
+			i := InterfaceDetail{
+				Id:              aws.ToString(eni.NetworkInterfaceId),
+				SubnetId:        aws.ToString(eni.SubnetId),
+				SourceDestCheck: aws.ToBool(eni.SourceDestCheck),
+				Description:     aws.ToString(eni.Description),
+			}
+			if eni.Association != nil {
+				i.PublicIp = aws.ToString(eni.Association.PublicIp)
+			}
+			for _, address := range eni.PrivateIpAddresses {
+				i.PrivateIps = append(i.PrivateIps, aws.ToString(address.PrivateIpAddress))
+			}
+			for _, group := range eni.Groups {
+				i.SecurityGroups = append(i.SecurityGroups, aws.ToString(group.GroupId))
+				groupIds[aws.ToString(group.GroupId)] = struct{}{}
+			}
+			detail.Interfaces = append(detail.Interfaces, i)
+		}
+		sort.Slice(detail.Interfaces, func(i, j int) bool { return detail.Interfaces[i].Id < detail.Interfaces[j].Id })
+	}
+
+	if len(groupIds) > 0 {
+		ids := make([]string, 0, len(groupIds))
+		for id := range groupIds {
+			ids = append(ids, id)
+		}
+		groups, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: ids})
+		if err != nil {
+			detail.Warnings = append(detail.Warnings, fmt.Sprintf("security groups: %v", err))
+		} else {
+			for _, group := range groups.SecurityGroups {
+				detail.SecurityGroups = append(detail.SecurityGroups, newSecurityGroupDetail(group))
+			}
+			sort.Slice(detail.SecurityGroups, func(i, j int) bool { return detail.SecurityGroups[i].Id < detail.SecurityGroups[j].Id })
+		}
+	}
+
+	if target.IamInstanceProfile != "" {
+		profile, err := iam.NewFromConfig(cfg).GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{InstanceProfileName: aws.String(target.IamInstanceProfile)})
+		if err != nil {
+			detail.Warnings = append(detail.Warnings, fmt.Sprintf("iam role: %v", err))
+		} else {
+			for _, role := range profile.InstanceProfile.Roles {
+				detail.Roles = append(detail.Roles, aws.ToString(role.Arn))
+			}
+		}
+	}
+
+	status, err := client.DescribeInstanceStatus(ctx, &ec2.DescribeInstanceStatusInput{
+		InstanceIds:         []string{target.Id},
+		IncludeAllInstances: aws.Bool(true),
+	})
+	if err != nil {
+		detail.Warnings = append(detail.Warnings, fmt.Sprintf("status checks: %v", err))
+	} else {
+		for _, s := range status.InstanceStatuses {
+			if s.SystemStatus != nil {
+				detail.SystemStatus = string(s.SystemStatus.Status)
+			}
+			if s.InstanceStatus != nil {
+				detail.InstanceStatus = string(s.InstanceStatus.Status)
+			}
+			for _, event := range s.Events {
+				detail.Events = append(detail.Events, fmt.Sprintf("%s: %s (%s)", event.Code, aws.ToString(event.Description), aws.ToTime(event.NotBefore).Local().Format("2006-01-02 15:04")))
+			}
+		}
+	}
+	return detail
+}
+
+func newSecurityGroupDetail(group types.SecurityGroup) SecurityGroupDetail {
+	detail := SecurityGroupDetail{Id: aws.ToString(group.GroupId), Name: aws.ToString(group.GroupName)}
+	for _, permission := range group.IpPermissions {
+		detail.Inbound = append(detail.Inbound, securityGroupRules(permission)...)
+	}
+	for _, permission := range group.IpPermissionsEgress {
+		detail.Outbound = append(detail.Outbound, securityGroupRules(permission)...)
+	}
+	return detail
+}
+
+// IpPermission 하나를 peer(CIDR, 보안 그룹, prefix list) 별 규칙으로 펼침
+func securityGroupRules(permission types.IpPermission) []SecurityGroupRule {
+	protocol := aws.ToString(permission.IpProtocol)
+	from, to := aws.ToInt32(permission.FromPort), aws.ToInt32(permission.ToPort)
+	ports := "all"
+	switch {
+	case protocol == "-1":
+		protocol = "all"
+	case permission.FromPort == nil || from == -1 || (from == 0 && to == 65535):
+	case protocol == "icmp" || protocol == "icmpv6":
+		ports = fmt.Sprintf("type %d code %d", from, to)
+	case from == to:
+		ports = fmt.Sprintf("%d", from)
+	default:
+		ports = fmt.Sprintf("%d-%d", from, to)
+	}
+
+	var rules []SecurityGroupRule
+	add := func(peer, description string) {
+		rules = append(rules, SecurityGroupRule{Protocol: protocol, Ports: ports, Peer: peer, Description: description})
+	}
+	for _, r := range permission.IpRanges {
+		add(aws.ToString(r.CidrIp), aws.ToString(r.Description))
+	}
+	for _, r := range permission.Ipv6Ranges {
+		add(aws.ToString(r.CidrIpv6), aws.ToString(r.Description))
+	}
+	for _, r := range permission.UserIdGroupPairs {
+		peer := aws.ToString(r.GroupId)
+		if r.UserId != nil && r.VpcPeeringConnectionId != nil {
+			peer = fmt.Sprintf("%s/%s", aws.ToString(r.UserId), peer)
+		}
+		add(peer, aws.ToString(r.Description))
+	}
+	for _, r := range permission.PrefixListIds {
+		add(aws.ToString(r.PrefixListId), aws.ToString(r.Description))
+	}
+	return rules
+}
+
+func PrintInstanceDetail(cmd, region string, detail *InstanceDetail) {
+	PrintEc2Target(cmd, region, detail.Target)
+
+	LogSection("Status Checks")
+	LogAttributes([][2]string{
+		{"System", detail.SystemStatus},
+		{"Instance", detail.InstanceStatus},
+		{"Events", strings.Join(detail.Events, "; ")},
+	})
+
+	if len(detail.Roles) > 0 {
+		LogSection("IAM Role")
+		for _, role := range detail.Roles {
+			fmt.Printf("  %s\n", role)
+		}
+	}
+
+	LogSection("Volumes")
+	rows := make([][]string, 0, len(detail.Volumes))
+	for _, v := range detail.Volumes {
+		performance := "-"
+		if v.Iops > 0 {
+			performance = fmt.Sprintf("%d iops", v.Iops)
+		}
+		if v.Throughput > 0 {
+			performance += fmt.Sprintf(", %d MiB/s", v.Throughput)
+		}
+		rows = append(rows, []string{v.Device, v.Id, fmt.Sprintf("%d GiB", v.Size), v.Type, performance, fmt.Sprintf("%t", v.Encrypted), v.State, fmt.Sprintf("%t", v.DeleteOnTermination)})
+	}
+	LogTable([]string{"DEVICE", "VOLUME", "SIZE", "TYPE", "PERFORMANCE", "ENCRYPTED", "STATE", "DELETE ON TERM"}, rows)
+
+	LogSection("Network Interfaces")
+	rows = rows[:0]
+	for _, i := range detail.Interfaces {
+		publicIp := i.PublicIp
+		if publicIp == "" {
+			publicIp = "-"
+		}
+		rows = append(rows, []string{i.Id, strings.Join(i.PrivateIps, ","), publicIp, i.SubnetId, strings.Join(i.SecurityGroups, ","), fmt.Sprintf("%t", i.SourceDestCheck), i.Description})
+	}
+	LogTable([]string{"ENI", "PRIVATE IP", "PUBLIC IP", "SUBNET", "SECURITY GROUPS", "SRC/DST CHECK", "DESCRIPTION"}, rows)
+
+	for _, group := range detail.SecurityGroups {
+		LogSection(fmt.Sprintf("Security Group %s (%s)", group.Id, group.Name))
+		rows = rows[:0]
+		for _, rule := range group.Inbound {
+			rows = append(rows, []string{"inbound", rule.Protocol, rule.Ports, rule.Peer, rule.Description})
+		}
+		for _, rule := range group.Outbound {
+			rows = append(rows, []string{"outbound", rule.Protocol, rule.Ports, rule.Peer, rule.Description})
+		}
+		LogTable([]string{"DIRECTION", "PROTOCOL", "PORTS", "PEER", "DESCRIPTION"}, rows)
+	}
+
+	for _, warning := range detail.Warnings {
+		LogWarning("조회 실패 - %s", warning)
+	}
+}
+
+// 시리얼 콘솔 로그 (Nitro 는 최신 64KB, 그 외는 부팅 직후 출력)
+func GetConsoleOutput(ctx context.Context, cfg aws.Config, instanceId string) (string, time.Time, error) {
+	client := ec2.NewFromConfig(cfg)
+	output, err := client.GetConsoleOutput(ctx, &ec2.GetConsoleOutputInput{InstanceId: aws.String(instanceId), Latest: aws.Bool(true)})
+	if err != nil {
+		// Latest 를 지원하지 않는 (Xen) 인스턴스
+		output, err = client.GetConsoleOutput(ctx, &ec2.GetConsoleOutputInput{InstanceId: aws.String(instanceId)})
+		if err != nil {
+			return "", time.Time{}, err
+		}
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(aws.ToString(output.Output))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return strings.ReplaceAll(string(decoded), "\r\n", "\n"), aws.ToTime(output.Timestamp), nil
+}
+
+// 콘솔 출력의 마지막 n 줄
+func TailLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// 콘솔 로그를 주기적으로 조회해 새로 추가된 부분만 출력 (ctx 취소 시 종료)
+func FollowConsoleOutput(ctx context.Context, cfg aws.Config, instanceId string, previous string, interval time.Duration, out io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, _, err := GetConsoleOutput(ctx, cfg, instanceId)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		fmt.Fprint(out, appendedConsoleOutput(previous, current))
+		previous = current
+	}
+}
+
+// 최신 64KB 만 내려오므로 앞부분이 잘려 나간 경우 새 출력의 시작 위치를 이전 출력에서 찾아 이어서 출력
+func appendedConsoleOutput(previous, current string) string {
+	if strings.HasPrefix(current, previous) {
+		return current[len(previous):]
+	}
+
+	head := current
+	if len(head) > consoleOverlap {
+		head = head[:consoleOverlap]
+	}
+
+	for offset := 0; offset < len(previous); {
+		index := strings.Index(previous[offset:], head)
+		if index < 0 {
+			break
+		}
+		start := offset + index
+		if strings.HasPrefix(current, previous[start:]) {
+			return current[len(previous)-start:]
+		}
+		offset = start + 1
+	}
+	return current
+}
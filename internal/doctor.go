@@ -0,0 +1,342 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+)
+
+type (
+	DoctorStatus string
+
+	DoctorCheck struct {
+		Name   string
+		Status DoctorStatus
+		Detail string
+		Fix    string
+	}
+
+	// mcl 기능별로 필요한 IAM 액션
+	FeaturePermission struct {
+		Feature string
+		Actions []string
+	}
+)
+
+const (
+	DoctorPass DoctorStatus = "PASS"
+	DoctorWarn DoctorStatus = "WARN"
+	DoctorFail DoctorStatus = "FAIL"
+	DoctorSkip DoctorStatus = "SKIP"
+
+	// SigV4는 5분 이상의 시각 차이를 거부
+	maxClockSkew  = 5 * time.Minute
+	warnClockSkew = 1 * time.Minute
+)
+
+var (
+	FeaturePermissions = []FeaturePermission{
+		{Feature: "ec2", Actions: []string{"ec2:DescribeInstances"}},
+		{Feature: "ec2 lifecycle", Actions: []string{"ec2:DescribeInstanceAttribute", "ec2:DescribeInstanceStatus", "ec2:StartInstances", "ec2:StopInstances", "ec2:RebootInstances", "ec2:TerminateInstances"}},
+		{Feature: "ec2 describe", Actions: []string{"ec2:DescribeVolumes", "ec2:DescribeNetworkInterfaces", "ec2:DescribeSecurityGroups", "ec2:DescribeInstanceStatus", "ec2:GetConsoleOutput", "iam:GetInstanceProfile"}},
+		{Feature: "ec2 rightsize", Actions: []string{"cloudwatch:GetMetricData", "cloudwatch:ListMetrics"}},
+		{Feature: "ec2 resize", Actions: []string{"ec2:DescribeInstanceTypes", "ec2:DescribeInstanceTypeOfferings", "ec2:StopInstances", "ec2:StartInstances", "ec2:ModifyInstanceAttribute", "ec2:DescribeInstanceStatus", "ec2:CreateImage", "ec2:DescribeImages"}},
+		{Feature: "ec2 password", Actions: []string{"ec2:GetPasswordData"}},
+		{Feature: "ec2 launch", Actions: []string{"ec2:DescribeLaunchTemplates", "ec2:DescribeLaunchTemplateVersions", "ec2:DescribeSubnets", "ec2:DescribeVolumes", "ec2:DescribeInstanceAttribute", "ec2:RunInstances", "ec2:CreateTags", "iam:PassRole"}},
+		{Feature: "ssm", Actions: []string{"ec2:DescribeInstances", "ssm:StartSession"}},
+		{Feature: "ec2 run", Actions: []string{"ssm:SendCommand", "ssm:GetCommandInvocation"}},
+		{Feature: "instance connect", Actions: []string{"ec2-instance-connect:SendSSHPublicKey"}},
+		{Feature: "cert (kms)", Actions: []string{"kms:GetPublicKey", "kms:Sign"}},
+		{Feature: "volume", Actions: []string{"ec2:DescribeInstances", "ec2:DescribeVolumes", "ec2:ModifyVolume", "ec2:DescribeVolumesModifications"}},
+		{Feature: "ami", Actions: []string{"ec2:CreateImage", "ec2:DescribeImages", "ec2:DeregisterImage", "ec2:DeleteSnapshot", "ec2:CreateTags"}},
+		{Feature: "tag", Actions: []string{"ec2:CreateTags", "ec2:DeleteTags", "rds:AddTagsToResource", "rds:RemoveTagsFromResource", "elasticache:ListTagsForResource", "elasticache:AddTagsToResource", "elasticache:RemoveTagsFromResource"}},
+		{Feature: "sg audit", Actions: []string{"ec2:DescribeSecurityGroups", "ec2:DescribeNetworkInterfaces", "ec2:DescribeStaleSecurityGroups", "rds:DescribeDBInstances", "elasticache:DescribeCacheClusters"}},
+		{Feature: "asg", Actions: []string{"autoscaling:DescribeAutoScalingGroups", "autoscaling:SetDesiredCapacity", "autoscaling:SuspendProcesses", "autoscaling:ResumeProcesses", "autoscaling:StartInstanceRefresh", "autoscaling:DescribeInstanceRefreshes", "autoscaling:CancelInstanceRefresh", "autoscaling:EnterStandby", "autoscaling:ExitStandby"}},
+		{Feature: "rds", Actions: []string{"rds:DescribeDBInstances"}},
+		{Feature: "elasticache", Actions: []string{"elasticache:DescribeCacheClusters"}},
+		{Feature: "s3", Actions: []string{"s3:ListAllMyBuckets", "s3:GetBucketLocation", "s3:ListBucket"}},
+		{Feature: "cloudfront", Actions: []string{"cloudfront:ListDistributions", "cloudfront:CreateInvalidation"}},
+		{Feature: "eks", Actions: []string{"eks:ListClusters", "eks:DescribeCluster"}},
+	}
+)
+
+// 외부 실행 파일 설치 여부 확인 (aws, session-manager-plugin, kubectl)
+func CheckBinaries() []DoctorCheck {
+	var checks []DoctorCheck
+
+	_, missing := CheckSSMClientInstalled()
+	missingSet := make(map[string]struct{}, len(missing))
+	for _, m := range missing {
+		missingSet[m] = struct{}{}
+	}
+
+	if _, ok := missingSet["awscli"]; ok {
+		checks = append(checks, DoctorCheck{Name: "aws cli", Status: DoctorFail, Detail: "`aws` not found in PATH",
+			Fix: "brew install awscli (https://docs.aws.amazon.com/cli/latest/userguide/getting-started-install.html)"})
+	} else {
+		checks = append(checks, DoctorCheck{Name: "aws cli", Status: DoctorPass, Detail: lookPathDetail("aws")})
+	}
+
+	if _, ok := missingSet["session-manager-plugin"]; ok {
+		checks = append(checks, DoctorCheck{Name: "session-manager-plugin", Status: DoctorFail, Detail: "`session-manager-plugin` not found in PATH",
+			Fix: "brew install --cask session-manager-plugin (https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html)"})
+	} else {
+		checks = append(checks, DoctorCheck{Name: "session-manager-plugin", Status: DoctorPass, Detail: lookPathDetail("session-manager-plugin")})
+	}
+
+	if _, err := exec.LookPath("kubectl"); err != nil {
+		checks = append(checks, DoctorCheck{Name: "kubectl", Status: DoctorWarn, Detail: "`kubectl` not found in PATH (required by `mcl eks`)",
+			Fix: "brew install kubectl (https://kubernetes.io/docs/tasks/tools/)"})
+	} else {
+		checks = append(checks, DoctorCheck{Name: "kubectl", Status: DoctorPass, Detail: lookPathDetail("kubectl")})
+	}
+
+	return checks
+}
+
+func lookPathDetail(name string) string {
+	path, _ := exec.LookPath(name)
+	return path
+}
+
+// 자격 증명 유효성 확인, 성공 시 호출자 ARN 반환
+func CheckCredentials(ctx context.Context, cfg aws.Config) (DoctorCheck, string) {
+	check := DoctorCheck{Name: "credentials"}
+
+	output, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		check.Status = DoctorFail
+		check.Detail = err.Error()
+
+		var apiErr smithy.APIError
+		switch {
+		case errors.As(err, &apiErr) && strings.Contains(apiErr.ErrorCode(), "ExpiredToken"):
+			check.Fix = "credentials expired: renew the session (aws sso login, aws-vault exec, or refresh ~/.aws/credentials)"
+		case errors.As(err, &apiErr) && apiErr.ErrorCode() == "SignatureDoesNotMatch":
+			check.Fix = "signature rejected: check the secret key and the local clock"
+		default:
+			check.Fix = "configure valid credentials (aws configure, aws-vault, or AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY)"
+		}
+		return check, ""
+	}
+
+	callerArn := aws.ToString(output.Arn)
+	check.Status = DoctorPass
+	check.Detail = fmt.Sprintf("%s (account %s)", callerArn, aws.ToString(output.Account))
+	return check, callerArn
+}
+
+// 로컬 시각과 AWS 서버 시각 차이 확인 (SigV4 서명 유효성)
+func CheckClockSkew(ctx context.Context, region string) DoctorCheck {
+	check := DoctorCheck{Name: "clock skew"}
+
+	endpoint := "https://sts.amazonaws.com/"
+	if region != "" {
+		endpoint = fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		check.Status = DoctorSkip
+		check.Detail = err.Error()
+		return check
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	sent := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		check.Status = DoctorSkip
+		check.Detail = fmt.Sprintf("cannot reach %s: %v", endpoint, err)
+		return check
+	}
+	defer resp.Body.Close()
+	received := time.Now()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		check.Status = DoctorSkip
+		check.Detail = "no Date header in response"
+		return check
+	}
+
+	localTime := sent.Add(received.Sub(sent) / 2)
+	skew := localTime.Sub(serverTime).Round(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	check.Detail = fmt.Sprintf("local clock differs from AWS by %s", skew)
+	switch {
+	case skew >= maxClockSkew:
+		check.Status = DoctorFail
+		check.Fix = "synchronize the system clock (macOS: sudo sntp -sS time.apple.com, Linux: sudo chronyc makestep)"
+	case skew >= warnClockSkew:
+		check.Status = DoctorWarn
+		check.Fix = "enable automatic time synchronization (NTP)"
+	default:
+		check.Status = DoctorPass
+	}
+	return check
+}
+
+// 인스턴스 키 페어별 ~/.ssh/<KeyName>.pem 존재 여부와 권한 확인
+func CheckKeyFiles(ctx context.Context, cfg aws.Config) []DoctorCheck {
+	instances, err := FindInstance(ctx, cfg)
+	if err != nil {
+		return []DoctorCheck{{Name: "ssh keys", Status: DoctorSkip, Detail: fmt.Sprintf("cannot list instances: %v", err)}}
+	}
+
+	usage := make(map[string]int)
+	for _, instance := range instances {
+		if instance.KeyName != "" {
+			usage[instance.KeyName]++
+		}
+	}
+	if len(usage) == 0 {
+		return []DoctorCheck{{Name: "ssh keys", Status: DoctorSkip, Detail: "no running instance uses a key pair"}}
+	}
+
+	keyNames := make([]string, 0, len(usage))
+	for keyName := range usage {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	checks := make([]DoctorCheck, 0, len(keyNames))
+	for _, keyName := range keyNames {
+		keyPath := filepath.Join(FindHomeFolder(), ".ssh", keyName+".pem")
+		check := DoctorCheck{Name: fmt.Sprintf("ssh key %s", keyName)}
+
+		info, err := os.Stat(keyPath)
+		switch {
+		case err != nil:
+			// pem 이 없으면 EC2 Instance Connect 로 대체 접속
+			check.Status = DoctorWarn
+			check.Detail = fmt.Sprintf("%s not found (used by %d instances, EC2 Instance Connect will be used)", keyPath, usage[keyName])
+			check.Fix = fmt.Sprintf("place the key pair at %s and run: chmod 400 %s, or make sure ec2-instance-connect is installed on the instances", keyPath, keyPath)
+		case info.Mode().Perm()&0077 != 0:
+			check.Status = DoctorFail
+			check.Detail = fmt.Sprintf("%s has permissions %04o (must not be accessible by group/others)", keyPath, info.Mode().Perm())
+			check.Fix = fmt.Sprintf("chmod 400 %s", keyPath)
+		default:
+			check.Status = DoctorPass
+			check.Detail = fmt.Sprintf("%s (used by %d instances)", keyPath, usage[keyName])
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// IAM 정책 시뮬레이션으로 기능별 필요 권한 확인
+func CheckFeaturePermissions(ctx context.Context, cfg aws.Config, callerArn string) []DoctorCheck {
+	client := iam.NewFromConfig(cfg)
+
+	principalArn, reason := resolvePrincipalArn(ctx, client, callerArn)
+	if principalArn == "" {
+		return []DoctorCheck{{Name: "iam permissions", Status: DoctorSkip, Detail: reason}}
+	}
+
+	actionSet := make(map[string]struct{})
+	for _, feature := range FeaturePermissions {
+		for _, action := range feature.Actions {
+			actionSet[action] = struct{}{}
+		}
+	}
+	actions := make([]string, 0, len(actionSet))
+	for action := range actionSet {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	decisions := make(map[string]iamTypes.PolicyEvaluationDecisionType, len(actions))
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(client, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalArn),
+		ActionNames:     actions,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return []DoctorCheck{{Name: "iam permissions", Status: DoctorSkip,
+				Detail: fmt.Sprintf("policy simulation failed: %v", err),
+				Fix:    "allow iam:SimulatePrincipalPolicy (and iam:GetRole) to run this check"}}
+		}
+		for _, result := range output.EvaluationResults {
+			decisions[aws.ToString(result.EvalActionName)] = result.EvalDecision
+		}
+	}
+
+	checks := make([]DoctorCheck, 0, len(FeaturePermissions))
+	for _, feature := range FeaturePermissions {
+		var denied []string
+		for _, action := range feature.Actions {
+			if decisions[action] != iamTypes.PolicyEvaluationDecisionTypeAllowed {
+				denied = append(denied, action)
+			}
+		}
+
+		check := DoctorCheck{Name: fmt.Sprintf("iam %s", feature.Feature)}
+		if len(denied) == 0 {
+			check.Status = DoctorPass
+			check.Detail = strings.Join(feature.Actions, ", ")
+		} else {
+			check.Status = DoctorFail
+			check.Detail = fmt.Sprintf("denied: %s", strings.Join(denied, ", "))
+			check.Fix = fmt.Sprintf("grant %s to %s", strings.Join(denied, ", "), principalArn)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// 시뮬레이션 가능한 IAM principal ARN으로 변환 (assumed-role -> role)
+func resolvePrincipalArn(ctx context.Context, client *iam.Client, callerArn string) (string, string) {
+	if callerArn == "" {
+		return "", "credentials are not valid"
+	}
+
+	parsed, err := arn.Parse(callerArn)
+	if err != nil {
+		return "", fmt.Sprintf("cannot parse caller arn %s", callerArn)
+	}
+
+	switch {
+	case parsed.Resource == "root":
+		return "", "root account has every permission"
+	case strings.HasPrefix(parsed.Resource, "user/"):
+		return callerArn, ""
+	case strings.HasPrefix(parsed.Resource, "assumed-role/"):
+		parts := strings.Split(parsed.Resource, "/")
+		if len(parts) < 2 {
+			return "", fmt.Sprintf("cannot parse caller arn %s", callerArn)
+		}
+		roleName := parts[1]
+
+		// 역할 경로(path)를 포함한 정확한 ARN 조회
+		output, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)})
+		if err == nil {
+			return aws.ToString(output.Role.Arn), ""
+		}
+		return fmt.Sprintf("arn:%s:iam::%s:role/%s", parsed.Partition, parsed.AccountID, roleName), ""
+	default:
+		return "", fmt.Sprintf("policy simulation is not supported for %s", callerArn)
+	}
+}
+
+func PrintDoctorCheck(check DoctorCheck) {
+	LogDoctorCheck(string(check.Status), check.Name, check.Detail, check.Fix)
+}
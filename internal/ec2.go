@@ -3,6 +3,9 @@ package internal
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,27 +17,162 @@ import (
 
 type (
 	Target struct {
-		Id        string
-		Name      string
-		PublicIp  string
-		PrivateIp string
-		Group     string
-		KeyName   string
+		Id                 string
+		Name               string
+		PublicIp           string
+		PrivateIp          string
+		Group              string
+		KeyName            string
+		State              string
+		InstanceType       string
+		ImageId            string
+		AvailabilityZone   string
+		LaunchTime         time.Time
+		Platform           string
+		VpcId              string
+		SubnetId           string
+		SecurityGroups     []string
+		IamInstanceProfile string
+		Lifecycle          string
+		Tags               map[string]string
+	}
+
+	// --tag key=value 필터 (값은 * ? 와일드카드 지원, 값이 없으면 태그 존재 여부만 확인)
+	TagFilter struct {
+		Key   string
+		Value string
+	}
+
+	InstanceFilter struct {
+		States []string
+		Tags   []TagFilter
 	}
 )
 
+const (
+	lifecycleOnDemand = "on-demand"
+
+	DefaultGroupTag = "Server-Group"
+	NoGroup         = "(none)"
+)
+
+var (
+	// terminated 는 조회 시 명시적으로 지정해야 포함
+	InstanceStates = []string{"pending", "running", "stopping", "stopped", "shutting-down", "terminated"}
+
+	groupTag = DefaultGroupTag
+)
+
+// 인스턴스 그룹으로 사용할 태그 이름 설정 (Environment, Service, aws:autoscaling:groupName 등)
+func SetGroupTag(key string) {
+	if key = strings.TrimSpace(key); key != "" {
+		groupTag = key
+	}
+}
+
+func GroupTag() string {
+	return groupTag
+}
+
+// --tag 값 파싱 (key=value 또는 key)
+func ParseTagFilters(values []string) ([]TagFilter, error) {
+	filters := make([]TagFilter, 0, len(values))
+	for _, value := range values {
+		key, tagValue, _ := strings.Cut(strings.TrimSpace(value), "=")
+		if key = strings.TrimSpace(key); key == "" {
+			return nil, fmt.Errorf("invalid tag filter %q (expected key=value)", value)
+		}
+		filters = append(filters, TagFilter{Key: key, Value: strings.TrimSpace(tagValue)})
+	}
+	return filters, nil
+}
+
+// EC2 서버 측 필터로 변환 (같은 키는 OR, 다른 키는 AND)
+func (f InstanceFilter) ec2Filters() []types.Filter {
+	filters := []types.Filter{
+		{
+			Name:   aws.String("instance-state-name"),
+			Values: f.States,
+		},
+	}
+	return append(filters, tagFilters(f.Tags)...)
+}
+
+// 태그 조건을 EC2 API 필터로 변환 (인스턴스, 볼륨 등 공통)
+func tagFilters(tags []TagFilter) []types.Filter {
+	var filters []types.Filter
+	var tagKeys, valueKeys []string
+	tagValues := make(map[string][]string)
+	for _, tag := range tags {
+		if tag.Value == "" {
+			if !slices.Contains(tagKeys, tag.Key) {
+				tagKeys = append(tagKeys, tag.Key)
+			}
+			continue
+		}
+		if _, ok := tagValues[tag.Key]; !ok {
+			valueKeys = append(valueKeys, tag.Key)
+		}
+		tagValues[tag.Key] = append(tagValues[tag.Key], tag.Value)
+	}
+	for _, key := range valueKeys {
+		filters = append(filters, types.Filter{Name: aws.String("tag:" + key), Values: tagValues[key]})
+	}
+	// 한 필터 안의 값은 OR 이므로 키만 있는 조건은 키마다 별도 필터 (AND)
+	for _, key := range tagKeys {
+		filters = append(filters, types.Filter{Name: aws.String("tag-key"), Values: []string{key}})
+	}
+	return filters
+}
+
+// --state 값 파싱 (콤마 구분, all 은 terminated 를 제외한 모든 상태)
+func ParseInstanceStates(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []string{"running"}, nil
+	}
+	if value == "all" {
+		return InstanceStates[:len(InstanceStates)-1], nil
+	}
+
+	var states []string
+	for _, state := range strings.Split(value, ",") {
+		state = strings.TrimSpace(state)
+		if state == "" {
+			continue
+		}
+		valid := false
+		for _, s := range InstanceStates {
+			if s == state {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid instance state %q (valid: all, %s)", state, strings.Join(InstanceStates, ", "))
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
 func FindInstance(ctx context.Context, cfg aws.Config) (map[string]*Target, error) {
+	return FindInstanceByState(ctx, cfg, []string{"running"})
+}
+
+// 지정한 상태(running, stopped, ...)의 EC2 인스턴스 조회
+func FindInstanceByState(ctx context.Context, cfg aws.Config, states []string) (map[string]*Target, error) {
+	return FindInstanceWithFilter(ctx, cfg, InstanceFilter{States: states})
+}
+
+// 상태와 태그 필터로 EC2 인스턴스 조회
+func FindInstanceWithFilter(ctx context.Context, cfg aws.Config, filter InstanceFilter) (map[string]*Target, error) {
 	client := ec2.NewFromConfig(cfg)
 	table := make(map[string]*Target)
 
 	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{
 		MaxResults: aws.Int32(maxOutputResults),
-		Filters: []types.Filter{
-			{
-				Name:   aws.String("instance-state-name"),
-				Values: []string{"running"},
-			},
-		},
+		Filters:    filter.ec2Filters(),
 	})
 
 	for paginator.HasMorePages() {
@@ -45,25 +183,8 @@ func FindInstance(ctx context.Context, cfg aws.Config) (map[string]*Target, erro
 
 		for _, reservation := range output.Reservations {
 			for _, instance := range reservation.Instances {
-				var name, group string
-				for _, tag := range instance.Tags {
-					switch aws.ToString(tag.Key) {
-					case "Name":
-						name = aws.ToString(tag.Value)
-					case "Server-Group":
-						group = aws.ToString(tag.Value)
-					}
-				}
-
-				instanceId := aws.ToString(instance.InstanceId)
-				table[instanceId] = &Target{
-					Id:        instanceId,
-					Name:      name,
-					PublicIp:  aws.ToString(instance.PublicIpAddress),
-					PrivateIp: aws.ToString(instance.PrivateIpAddress),
-					Group:     group,
-					KeyName:   aws.ToString(instance.KeyName),
-				}
+				target := newTarget(instance)
+				table[target.Id] = target
 			}
 		}
 	}
@@ -71,6 +192,117 @@ func FindInstance(ctx context.Context, cfg aws.Config) (map[string]*Target, erro
 	return table, nil
 }
 
+// 그룹 태그 값별 인스턴스 수 (그룹 태그가 없는 인스턴스는 NoGroup)
+func CountInstanceGroups(ctx context.Context, cfg aws.Config, filter InstanceFilter) (map[string]int, error) {
+	table, err := FindInstanceWithFilter(ctx, cfg, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]int)
+	for _, target := range table {
+		group := target.Group
+		if group == "" {
+			group = NoGroup
+		}
+		groups[group]++
+	}
+	return groups, nil
+}
+
+func newTarget(instance types.Instance) *Target {
+	tags := make(map[string]string, len(instance.Tags))
+	for _, tag := range instance.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	var state string
+	if instance.State != nil {
+		state = string(instance.State.Name)
+	}
+
+	var availabilityZone string
+	if instance.Placement != nil {
+		availabilityZone = aws.ToString(instance.Placement.AvailabilityZone)
+	}
+
+	securityGroups := make([]string, 0, len(instance.SecurityGroups))
+	for _, group := range instance.SecurityGroups {
+		securityGroups = append(securityGroups, aws.ToString(group.GroupId))
+	}
+
+	// arn:aws:iam::<account>:instance-profile/<name>
+	var iamInstanceProfile string
+	if instance.IamInstanceProfile != nil {
+		arn := aws.ToString(instance.IamInstanceProfile.Arn)
+		iamInstanceProfile = arn[strings.LastIndex(arn, "/")+1:]
+	}
+
+	lifecycle := string(instance.InstanceLifecycle)
+	if lifecycle == "" {
+		lifecycle = lifecycleOnDemand
+	}
+
+	return &Target{
+		Id:                 aws.ToString(instance.InstanceId),
+		Name:               tags["Name"],
+		PublicIp:           aws.ToString(instance.PublicIpAddress),
+		PrivateIp:          aws.ToString(instance.PrivateIpAddress),
+		Group:              tags[groupTag],
+		KeyName:            aws.ToString(instance.KeyName),
+		State:              state,
+		InstanceType:       string(instance.InstanceType),
+		ImageId:            aws.ToString(instance.ImageId),
+		AvailabilityZone:   availabilityZone,
+		LaunchTime:         aws.ToTime(instance.LaunchTime),
+		Platform:           aws.ToString(instance.PlatformDetails),
+		VpcId:              aws.ToString(instance.VpcId),
+		SubnetId:           aws.ToString(instance.SubnetId),
+		SecurityGroups:     securityGroups,
+		IamInstanceProfile: iamInstanceProfile,
+		Lifecycle:          lifecycle,
+		Tags:               tags,
+	}
+}
+
+// 선택 목록에 표시할 인스턴스 요약
+func (t *Target) Summary() string {
+	return fmt.Sprintf("%s (%s) - %s, %s, %s", t.Name, t.Id, t.State, t.InstanceType, t.AvailabilityZone)
+}
+
+// 기본 출력 외 상세 속성 (출력 및 대시보드 상세에서 사용)
+func (t *Target) Attributes() [][2]string {
+	var launchTime string
+	if !t.LaunchTime.IsZero() {
+		launchTime = t.LaunchTime.Local().Format("2006-01-02 15:04:05")
+	}
+
+	return [][2]string{
+		{"State", t.State},
+		{"Type", t.InstanceType},
+		{"AMI", t.ImageId},
+		{"AZ", t.AvailabilityZone},
+		{"Launch Time", launchTime},
+		{"Platform", t.Platform},
+		{"VPC", t.VpcId},
+		{"Subnet", t.SubnetId},
+		{"Security Groups", strings.Join(t.SecurityGroups, ", ")},
+		{"IAM Profile", t.IamInstanceProfile},
+		{"Lifecycle", t.Lifecycle},
+		{"Tags", t.TagString()},
+	}
+}
+
+// key=value 형식으로 정렬된 태그 목록
+func (t *Target) TagString() string {
+	pairs := make([]string, 0, len(t.Tags))
+	for key, value := range t.Tags {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
 func GetInstancesWithHighUsage(ctx context.Context, instances map[string]*Target, bastionClient *ssh.Client, thresholdPercentage int) ([]*Target, map[*Target]int, error) {
 	type usageResult struct {
 		target *Target
@@ -150,25 +382,8 @@ func FindInstanceWithPaging(ctx context.Context, cfg aws.Config, page int) (map[
 
 		for _, reservation := range output.Reservations {
 			for _, instance := range reservation.Instances {
-				var name, group string
-				for _, tag := range instance.Tags {
-					switch aws.ToString(tag.Key) {
-					case "Name":
-						name = aws.ToString(tag.Value)
-					case "Server-Group":
-						group = aws.ToString(tag.Value)
-					}
-				}
-
-				instanceId := aws.ToString(instance.InstanceId)
-				table[instanceId] = &Target{
-					Id:        instanceId,
-					Name:      name,
-					PublicIp:  aws.ToString(instance.PublicIpAddress),
-					PrivateIp: aws.ToString(instance.PrivateIpAddress),
-					Group:     group,
-					KeyName:   aws.ToString(instance.KeyName),
-				}
+				target := newTarget(instance)
+				table[target.Id] = target
 			}
 		}
 	}
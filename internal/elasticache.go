@@ -7,16 +7,18 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
 )
 
 type (
 	ElastiCacheTarget struct {
-		Name     string
-		Endpoint string
-		Id       string
-		Status   string
-		Engine   string
-		Port     int32
+		Name           string
+		Endpoint       string
+		Id             string
+		Status         string
+		Engine         string
+		Port           int32
+		SecurityGroups []string
 	}
 )
 
@@ -25,7 +27,8 @@ func FindElastiCacheCluster(ctx context.Context, cfg aws.Config) (map[string]*El
 	table := make(map[string]*ElastiCacheTarget)
 
 	paginator := elasticache.NewDescribeCacheClustersPaginator(client, &elasticache.DescribeCacheClustersInput{
-		MaxRecords: aws.Int32(maxOutputResults),
+		MaxRecords:        aws.Int32(maxOutputResults),
+		ShowCacheNodeInfo: aws.Bool(true),
 	})
 
 	for paginator.HasMorePages() {
@@ -35,39 +38,51 @@ func FindElastiCacheCluster(ctx context.Context, cfg aws.Config) (map[string]*El
 		}
 
 		for _, cluster := range output.CacheClusters {
-			name := aws.ToString(cluster.CacheClusterId)
-			clusterId := aws.ToString(cluster.CacheClusterId)
-
-			var endpoint string
-			var port int32
-
-			// ConfigurationEndpoint가 nil이 아닌 경우에만 접근
-			if cluster.ConfigurationEndpoint != nil {
-				endpoint = aws.ToString(cluster.ConfigurationEndpoint.Address)
-				port = aws.ToInt32(cluster.ConfigurationEndpoint.Port)
-			}
-
-			table[clusterId] = &ElastiCacheTarget{
-				Name:     name,
-				Endpoint: endpoint,
-				Id:       clusterId,
-				Status:   aws.ToString(cluster.CacheClusterStatus),
-				Engine:   aws.ToString(cluster.Engine),
-				Port:     port,
-			}
+			target := newElastiCacheTarget(cluster)
+			table[target.Id] = target
 		}
 	}
 
 	return table, nil
 }
 
+func newElastiCacheTarget(cluster types.CacheCluster) *ElastiCacheTarget {
+	var endpoint string
+	var port int32
+
+	// 클러스터 모드는 ConfigurationEndpoint, 그 외에는 첫 번째 노드 Endpoint 사용
+	if cluster.ConfigurationEndpoint != nil {
+		endpoint = aws.ToString(cluster.ConfigurationEndpoint.Address)
+		port = aws.ToInt32(cluster.ConfigurationEndpoint.Port)
+	} else if len(cluster.CacheNodes) > 0 && cluster.CacheNodes[0].Endpoint != nil {
+		endpoint = aws.ToString(cluster.CacheNodes[0].Endpoint.Address)
+		port = aws.ToInt32(cluster.CacheNodes[0].Endpoint.Port)
+	}
+
+	securityGroups := make([]string, 0, len(cluster.SecurityGroups))
+	for _, group := range cluster.SecurityGroups {
+		securityGroups = append(securityGroups, aws.ToString(group.SecurityGroupId))
+	}
+
+	return &ElastiCacheTarget{
+		Name:           aws.ToString(cluster.CacheClusterId),
+		Endpoint:       endpoint,
+		Id:             aws.ToString(cluster.CacheClusterId),
+		Status:         aws.ToString(cluster.CacheClusterStatus),
+		Engine:         aws.ToString(cluster.Engine),
+		Port:           port,
+		SecurityGroups: securityGroups,
+	}
+}
+
 // 페이징을 지원하는 ElastiCache 클러스터 조회
 func FindElastiCacheClusterWithPaging(ctx context.Context, cfg aws.Config, page int) (map[string]*ElastiCacheTarget, error) {
 	client := elasticache.NewFromConfig(cfg)
 	table := make(map[string]*ElastiCacheTarget)
 
 	paginator := elasticache.NewDescribeCacheClustersPaginator(client, &elasticache.DescribeCacheClustersInput{
-		MaxRecords: aws.Int32(maxOutputResults),
+		MaxRecords:        aws.Int32(maxOutputResults),
+		ShowCacheNodeInfo: aws.Bool(true),
 	})
 
 	// 지정된 페이지까지 스킵
@@ -88,26 +103,8 @@ func FindElastiCacheClusterWithPaging(ctx context.Context, cfg aws.Config, page
 		}
 
 		for _, cluster := range output.CacheClusters {
-			name := aws.ToString(cluster.CacheClusterId)
-			clusterId := aws.ToString(cluster.CacheClusterId)
-
-			var endpoint string
-			var port int32
-
-			// ConfigurationEndpoint가 nil이 아닌 경우에만 접근
-			if cluster.ConfigurationEndpoint != nil {
-				endpoint = aws.ToString(cluster.ConfigurationEndpoint.Address)
-				port = aws.ToInt32(cluster.ConfigurationEndpoint.Port)
-			}
-
-			table[clusterId] = &ElastiCacheTarget{
-				Name:     name,
-				Endpoint: endpoint,
-				Id:       clusterId,
-				Status:   aws.ToString(cluster.CacheClusterStatus),
-				Engine:   aws.ToString(cluster.Engine),
-				Port:     port,
-			}
+			target := newElastiCacheTarget(cluster)
+			table[target.Id] = target
 		}
 	}
 
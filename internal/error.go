@@ -6,15 +6,45 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync/atomic"
 
 	"github.com/masuldev/merrwrap"
 )
 
+// 인터랙티브 메뉴 세션 중에는 프로세스를 종료하지 않고 메뉴로 복귀
+// RealPanic 은 명령어를 실행하는 고루틴에서만 호출 (워커 고루틴은 에러를 반환)
+var recoverable atomic.Bool
+
+type sessionAbort struct {
+	err error
+}
+
 func RealPanic(err error) {
 	LogError("err: %s", err.Error())
+	if recoverable.Load() {
+		panic(sessionAbort{err: err})
+	}
 	os.Exit(1)
 }
 
+// f 실행 중 RealPanic이 호출되면 종료 대신 에러를 반환
+func RunRecoverable(f func()) (err error) {
+	recoverable.Store(true)
+	defer func() {
+		recoverable.Store(false)
+		if r := recover(); r != nil {
+			abort, ok := r.(sessionAbort)
+			if !ok {
+				panic(r)
+			}
+			err = abort.err
+		}
+	}()
+
+	f()
+	return nil
+}
+
 func PrintError(err error) {
 	LogError("err: %s", err.Error())
 }
@@ -0,0 +1,217 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"golang.org/x/crypto/ssh"
+)
+
+type (
+	ExecOptions struct {
+		User    string
+		Sudo    bool
+		Timeout time.Duration
+	}
+
+	ExecResult struct {
+		Target   *Target       `json:"-"`
+		Id       string        `json:"id"`
+		Name     string        `json:"name"`
+		Ip       string        `json:"ip"`
+		ExitCode int           `json:"exitCode"`
+		Duration time.Duration `json:"-"`
+		Elapsed  string        `json:"duration"`
+		Stdout   string        `json:"stdout,omitempty"`
+		Stderr   string        `json:"stderr,omitempty"`
+		Error    string        `json:"error,omitempty"`
+	}
+
+	// 줄 단위로 "[호스트] " 접두어를 붙여 출력 (여러 호스트 출력이 섞이지 않도록 공유 잠금 사용)
+	prefixWriter struct {
+		prefix string
+		out    io.Writer
+		mu     *sync.Mutex
+		buf    []byte
+	}
+)
+
+const (
+	// 연결 실패, 시간 초과 등 원격 종료 코드를 알 수 없는 경우
+	ExecExitUnknown = -1
+)
+
+var (
+	prefixColors = []func(format string, a ...interface{}) string{
+		color.CyanString, color.GreenString, color.YellowString, color.MagentaString, color.BlueString,
+	}
+)
+
+// bastion 경유로 타겟에서 명령 실행 (stdout/stderr 는 전달받은 writer 로 스트리밍)
+func RunRemoteCommand(ctx context.Context, bastion *ssh.Client, target *Target, command string, options ExecOptions, stdout, stderr io.Writer) (int, error) {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	targetClient, err := connectTarget(bastion, target, options.User)
+	if err != nil {
+		return ExecExitUnknown, err
+	}
+	defer targetClient.Close()
+
+	session, err := targetClient.NewSession()
+	if err != nil {
+		return ExecExitUnknown, err
+	}
+	defer session.Close()
+
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	if options.Sudo {
+		command = "sudo -n sh -c " + shellQuote(command)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(command)
+	}()
+
+	select {
+	case err := <-done:
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitStatus(), nil
+		}
+		if err != nil {
+			return ExecExitUnknown, err
+		}
+		return 0, nil
+	case <-ctx.Done():
+		// 세션과 연결을 닫아 원격 명령 대기를 중단
+		// Run 은 출력 복사가 끝나야 반환하므로, 반환 후에는 stdout/stderr 에 더 이상 쓰지 않음
+		session.Signal(ssh.SIGKILL)
+		session.Close()
+		targetClient.Close()
+		<-done
+		return ExecExitUnknown, fmt.Errorf("timed out after %s", options.Timeout)
+	}
+}
+
+// 여러 인스턴스에서 명령 실행, stream 이 true 면 호스트 접두어를 붙여 실시간 출력
+func RunRemoteCommands(ctx context.Context, bastion *ssh.Client, targets []*Target, command string, options ExecOptions, concurrency int, stream bool, out io.Writer) []ExecResult {
+	width := 0
+	for _, target := range targets {
+		if l := len(execHostLabel(target)); l > width {
+			width = l
+		}
+	}
+
+	var mu sync.Mutex
+	results := make([]ExecResult, len(targets))
+	colorIndex := make(map[string]int, len(targets))
+	for i, target := range targets {
+		colorIndex[target.Id] = i
+	}
+
+	RunBatch(ctx, targets, concurrency, func(ctx context.Context, target *Target) (string, error) {
+		var stdoutBuf, stderrBuf bytes.Buffer
+		var stdout, stderr io.Writer = &stdoutBuf, &stderrBuf
+		var flushers []*prefixWriter
+		if stream {
+			i := colorIndex[target.Id]
+			prefix := prefixColors[i%len(prefixColors)]("[%-*s] ", width, execHostLabel(target))
+			stdoutWriter := &prefixWriter{prefix: prefix, out: out, mu: &mu}
+			stderrWriter := &prefixWriter{prefix: prefix, out: out, mu: &mu}
+			stdout, stderr = stdoutWriter, stderrWriter
+			flushers = append(flushers, stdoutWriter, stderrWriter)
+		}
+
+		started := time.Now()
+		exitCode, err := RunRemoteCommand(ctx, bastion, target, command, options, stdout, stderr)
+		for _, f := range flushers {
+			f.Flush()
+		}
+
+		result := ExecResult{
+			Target:   target,
+			Id:       target.Id,
+			Name:     target.Name,
+			Ip:       target.PrivateIp,
+			ExitCode: exitCode,
+			Duration: time.Since(started),
+			Stdout:   stdoutBuf.String(),
+			Stderr:   stderrBuf.String(),
+		}
+		result.Elapsed = result.Duration.Round(time.Millisecond).String()
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results[colorIndex[target.Id]] = result
+		return "", err
+	})
+
+	return results
+}
+
+func execHostLabel(target *Target) string {
+	if target.Name != "" {
+		return target.Name
+	}
+	return target.Id
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.writeLine(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// 줄바꿈 없이 끝난 마지막 출력 내보내기
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprint(w.out, w.prefix)
+	w.out.Write(line)
+}
+
+// sh -c 인자로 안전하게 전달하도록 작은따옴표로 감싸기
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// 호스트별 종료 코드, 소요 시간 요약 표 출력
+func PrintExecSummary(results []ExecResult) {
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		exitCode := fmt.Sprintf("%d", result.ExitCode)
+		if result.ExitCode == ExecExitUnknown {
+			exitCode = "-"
+		}
+		rows = append(rows, []string{result.Name, result.Id, result.Ip, exitCode, result.Elapsed, result.Error})
+	}
+	LogTable([]string{"NAME", "ID", "IP", "EXIT", "DURATION", "ERROR"}, rows)
+}
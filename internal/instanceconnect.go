@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2instanceconnect"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	InstanceConnectAuto   = "auto"
+	InstanceConnectAlways = "always"
+	InstanceConnectNever  = "never"
+
+	// 전송한 공개키는 60초 동안만 유효하므로 여유를 두고 재전송
+	instanceConnectKeyTTL = 45 * time.Second
+)
+
+var (
+	InstanceConnectModes = []string{InstanceConnectAuto, InstanceConnectAlways, InstanceConnectNever}
+
+	instanceConnect = struct {
+		sync.Mutex
+		cfg    *aws.Config
+		mode   string
+		pushed map[string]time.Time
+	}{mode: InstanceConnectAuto, pushed: make(map[string]time.Time)}
+
+	ephemeralKeyOnce   sync.Once
+	ephemeralKeySigner ssh.Signer
+	ephemeralKeyErr    error
+)
+
+// EC2 Instance Connect 사용 방식 설정 (auto: pem 이 없을 때만, always: 항상, never: 사용 안 함)
+func SetInstanceConnect(cfg *aws.Config, mode string) error {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = InstanceConnectAuto
+	}
+
+	valid := false
+	for _, m := range InstanceConnectModes {
+		valid = valid || m == mode
+	}
+	if !valid {
+		return fmt.Errorf("invalid instance connect mode %q (one of %s)", mode, strings.Join(InstanceConnectModes, ", "))
+	}
+
+	instanceConnect.Lock()
+	defer instanceConnect.Unlock()
+	instanceConnect.cfg = cfg
+	instanceConnect.mode = mode
+	return nil
+}
+
+// 프로세스 동안만 쓰는 ed25519 키 (디스크에 저장하지 않음)
+func ephemeralKey() (ssh.Signer, error) {
+	ephemeralKeyOnce.Do(func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			ephemeralKeyErr = err
+			return
+		}
+		ephemeralKeySigner, ephemeralKeyErr = ssh.NewSignerFromKey(privateKey)
+	})
+	return ephemeralKeySigner, ephemeralKeyErr
+}
+
+func instanceConnectSettings() (string, *aws.Config) {
+	instanceConnect.Lock()
+	defer instanceConnect.Unlock()
+	return instanceConnect.mode, instanceConnect.cfg
+}
+
+// SendSSHPublicKey 로 임시 공개키를 인스턴스 메타데이터에 등록 (유효 시간 내 재접속은 재전송 생략)
+func pushInstanceConnectKey(cfg aws.Config, target *Target, user string, signer ssh.Signer) error {
+	key := target.Id + "/" + user
+
+	instanceConnect.Lock()
+	pushedAt, ok := instanceConnect.pushed[key]
+	instanceConnect.Unlock()
+	if ok && time.Since(pushedAt) < instanceConnectKeyTTL {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sshDialTimeout)
+	defer cancel()
+
+	client := ec2instanceconnect.NewFromConfig(cfg)
+	output, err := client.SendSSHPublicKey(ctx, &ec2instanceconnect.SendSSHPublicKeyInput{
+		InstanceId:     aws.String(target.Id),
+		InstanceOSUser: aws.String(user),
+		SSHPublicKey:   aws.String(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))),
+	})
+	if err != nil {
+		return fmt.Errorf("ec2 instance connect: send ssh public key to %s (%s): %w", target.Id, user, err)
+	}
+	if !output.Success {
+		return fmt.Errorf("ec2 instance connect: %s rejected ssh public key (request %s)", target.Id, aws.ToString(output.RequestId))
+	}
+
+	instanceConnect.Lock()
+	instanceConnect.pushed[key] = time.Now()
+	instanceConnect.Unlock()
+	return nil
+}
+
+// 임시 키 생성 후 EC2 Instance Connect 로 전송
+func instanceConnectSigner(cfg *aws.Config, target *Target, user string) (ssh.Signer, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("ec2 instance connect requires aws config")
+	}
+	signer, err := ephemeralKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := pushInstanceConnectKey(*cfg, target, user, signer); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
@@ -0,0 +1,474 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// 만료 시각(RFC3339) 과 만료 시 동작(stop / terminate), 정리 자동화에서 사용
+	TTLExpiresTag = "TTL-Expires"
+	TTLActionTag  = "TTL-Action"
+
+	TTLActionStop      = "stop"
+	TTLActionTerminate = "terminate"
+)
+
+type (
+	LaunchTemplate struct {
+		Id             string
+		Name           string
+		DefaultVersion int64
+		LatestVersion  int64
+	}
+
+	LaunchTemplateVersion struct {
+		Number       int64
+		Description  string
+		Default      bool
+		InstanceType string
+		CreateTime   time.Time
+	}
+
+	Subnet struct {
+		Id               string
+		Name             string
+		VpcId            string
+		AvailabilityZone string
+		CidrBlock        string
+		AvailableIps     int32
+	}
+
+	LaunchOptions struct {
+		// 템플릿으로 시작하거나 (TemplateId + TemplateVersion) 기존 인스턴스 설정을 복제 (Source)
+		TemplateId      string
+		TemplateVersion string
+		Source          *Target
+
+		InstanceType string
+		Subnet       *Subnet
+		Name         string
+		Tags         map[string]string
+		TTL          time.Duration
+		TTLAction    string
+	}
+)
+
+func FindLaunchTemplates(ctx context.Context, cfg aws.Config) ([]*LaunchTemplate, error) {
+	var templates []*LaunchTemplate
+	paginator := ec2.NewDescribeLaunchTemplatesPaginator(ec2.NewFromConfig(cfg), &ec2.DescribeLaunchTemplatesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range output.LaunchTemplates {
+			templates = append(templates, &LaunchTemplate{
+				Id:             aws.ToString(t.LaunchTemplateId),
+				Name:           aws.ToString(t.LaunchTemplateName),
+				DefaultVersion: aws.ToInt64(t.DefaultVersionNumber),
+				LatestVersion:  aws.ToInt64(t.LatestVersionNumber),
+			})
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// 최신 버전부터
+func FindLaunchTemplateVersions(ctx context.Context, cfg aws.Config, templateId string) ([]*LaunchTemplateVersion, error) {
+	var versions []*LaunchTemplateVersion
+	paginator := ec2.NewDescribeLaunchTemplateVersionsPaginator(ec2.NewFromConfig(cfg), &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(templateId),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range output.LaunchTemplateVersions {
+			version := &LaunchTemplateVersion{
+				Number:      aws.ToInt64(v.VersionNumber),
+				Description: aws.ToString(v.VersionDescription),
+				Default:     aws.ToBool(v.DefaultVersion),
+				CreateTime:  aws.ToTime(v.CreateTime),
+			}
+			if v.LaunchTemplateData != nil {
+				version.InstanceType = string(v.LaunchTemplateData.InstanceType)
+			}
+			versions = append(versions, version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Number > versions[j].Number })
+	return versions, nil
+}
+
+func AskLaunchTemplate(templates []*LaunchTemplate, message string) (*LaunchTemplate, error) {
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no launch template found")
+	}
+
+	options := make([]string, 0, len(templates))
+	templateMap := make(map[string]*LaunchTemplate, len(templates))
+	for _, t := range templates {
+		option := fmt.Sprintf("%s (%s, default v%d, latest v%d)", t.Name, t.Id, t.DefaultVersion, t.LatestVersion)
+		options = append(options, option)
+		templateMap[option] = t
+	}
+
+	selected, err := AskMenu(message, options)
+	if err != nil {
+		return nil, err
+	}
+	return templateMap[selected], nil
+}
+
+// "$Default", "$Latest" 또는 버전 번호
+func AskLaunchTemplateVersion(versions []*LaunchTemplateVersion, message string) (string, error) {
+	options := []string{"$Default", "$Latest"}
+	versionMap := map[string]string{"$Default": "$Default", "$Latest": "$Latest"}
+	for _, v := range versions {
+		option := fmt.Sprintf("v%d", v.Number)
+		if v.Default {
+			option += " (default)"
+		}
+		if v.InstanceType != "" {
+			option += " " + v.InstanceType
+		}
+		if v.Description != "" {
+			option += " - " + v.Description
+		}
+		options = append(options, option)
+		versionMap[option] = strconv.FormatInt(v.Number, 10)
+	}
+
+	selected, err := AskMenu(message, options)
+	if err != nil {
+		return "", err
+	}
+	return versionMap[selected], nil
+}
+
+// 서브넷 ID 또는 Name 태그(glob) 로 검색, 여러 개면 선택
+func ResolveSubnet(ctx context.Context, cfg aws.Config, query string) (*Subnet, error) {
+	var subnets []*Subnet
+	paginator := ec2.NewDescribeSubnetsPaginator(ec2.NewFromConfig(cfg), &ec2.DescribeSubnetsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range output.Subnets {
+			subnet := &Subnet{
+				Id:               aws.ToString(s.SubnetId),
+				VpcId:            aws.ToString(s.VpcId),
+				AvailabilityZone: aws.ToString(s.AvailabilityZone),
+				CidrBlock:        aws.ToString(s.CidrBlock),
+				AvailableIps:     aws.ToInt32(s.AvailableIpAddressCount),
+			}
+			for _, tag := range s.Tags {
+				if aws.ToString(tag.Key) == "Name" {
+					subnet.Name = aws.ToString(tag.Value)
+				}
+			}
+			if matched, _ := path.Match(query, subnet.Name); subnet.Id == query || matched {
+				subnets = append(subnets, subnet)
+			}
+		}
+	}
+
+	switch len(subnets) {
+	case 0:
+		return nil, fmt.Errorf("no subnet matches %q", query)
+	case 1:
+		return subnets[0], nil
+	}
+
+	sort.Slice(subnets, func(i, j int) bool { return subnets[i].Name+subnets[i].Id < subnets[j].Name+subnets[j].Id })
+	options := make([]string, 0, len(subnets))
+	subnetMap := make(map[string]*Subnet, len(subnets))
+	for _, s := range subnets {
+		option := fmt.Sprintf("%s (%s) - %s, %s, %s, %d IPs free", s.Name, s.Id, s.VpcId, s.AvailabilityZone, s.CidrBlock, s.AvailableIps)
+		options = append(options, option)
+		subnetMap[option] = s
+	}
+	selected, err := AskMenu("서브넷을 선택하세요:", options)
+	if err != nil {
+		return nil, err
+	}
+	return subnetMap[selected], nil
+}
+
+// 템플릿 또는 원본 인스턴스 설정으로 인스턴스 1개 시작 (인스턴스 ID 반환)
+func LaunchInstance(ctx context.Context, cfg aws.Config, options LaunchOptions) (string, error) {
+	client := ec2.NewFromConfig(cfg)
+
+	input := &ec2.RunInstancesInput{
+		MinCount: aws.Int32(1),
+		MaxCount: aws.Int32(1),
+	}
+	tags := make(map[string]string)
+
+	switch {
+	case options.TemplateId != "":
+		input.LaunchTemplate = &types.LaunchTemplateSpecification{
+			LaunchTemplateId: aws.String(options.TemplateId),
+			Version:          aws.String(options.TemplateVersion),
+		}
+	case options.Source != nil:
+		if err := cloneInstanceConfig(ctx, client, options.Source.Id, input, tags); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("launch template or source instance is required")
+	}
+
+	if options.InstanceType != "" {
+		input.InstanceType = types.InstanceType(options.InstanceType)
+	}
+	if options.Subnet != nil {
+		if err := CheckCloneSubnet(options.Source, options.Subnet); err != nil {
+			return "", err
+		}
+		if err := overrideSubnet(ctx, client, options, input); err != nil {
+			return "", err
+		}
+	}
+
+	for key, value := range options.Tags {
+		tags[key] = value
+	}
+	if options.Name != "" {
+		tags["Name"] = options.Name
+	}
+	if options.TTL > 0 {
+		tags[TTLExpiresTag] = time.Now().Add(options.TTL).UTC().Format(time.RFC3339)
+		tags[TTLActionTag] = options.TTLAction
+	}
+	if len(tags) > 0 {
+		keys := make([]string, 0, len(tags))
+		for key := range tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var ec2Tags []types.Tag
+		for _, key := range keys {
+			ec2Tags = append(ec2Tags, types.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+		}
+		input.TagSpecifications = []types.TagSpecification{
+			{ResourceType: types.ResourceTypeInstance, Tags: ec2Tags},
+			{ResourceType: types.ResourceTypeVolume, Tags: ec2Tags},
+		}
+	}
+
+	output, err := client.RunInstances(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	if len(output.Instances) == 0 {
+		return "", fmt.Errorf("no instance launched")
+	}
+	return aws.ToString(output.Instances[0].InstanceId), nil
+}
+
+// 템플릿에 네트워크 인터페이스가 있으면 인스턴스 수준 SubnetId 와 함께 쓸 수 없으므로 인터페이스의 서브넷을 변경
+func overrideSubnet(ctx context.Context, client *ec2.Client, options LaunchOptions, input *ec2.RunInstancesInput) error {
+	if options.TemplateId == "" {
+		input.SubnetId = aws.String(options.Subnet.Id)
+		return nil
+	}
+
+	output, err := client.DescribeLaunchTemplateVersions(ctx, &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(options.TemplateId),
+		Versions:         []string{options.TemplateVersion},
+	})
+	if err != nil {
+		return err
+	}
+	if len(output.LaunchTemplateVersions) == 0 || output.LaunchTemplateVersions[0].LaunchTemplateData == nil {
+		return fmt.Errorf("launch template %s version %s not found", options.TemplateId, options.TemplateVersion)
+	}
+
+	interfaces := output.LaunchTemplateVersions[0].LaunchTemplateData.NetworkInterfaces
+	switch {
+	case len(interfaces) == 0:
+		input.SubnetId = aws.String(options.Subnet.Id)
+		return nil
+	case len(interfaces) > 1:
+		return fmt.Errorf("launch template %s defines %d network interfaces; --subnet can only override a single interface", options.TemplateId, len(interfaces))
+	case interfaces[0].NetworkInterfaceId != nil:
+		return fmt.Errorf("launch template %s attaches existing network interface %s; --subnet cannot be used", options.TemplateId, aws.ToString(interfaces[0].NetworkInterfaceId))
+	}
+
+	// 요청의 인터페이스 목록이 템플릿 것을 대체하므로 서브넷에 묶인 주소를 제외한 설정은 그대로 복사
+	t := interfaces[0]
+	input.NetworkInterfaces = []types.InstanceNetworkInterfaceSpecification{{
+		DeviceIndex:                    aws.Int32(aws.ToInt32(t.DeviceIndex)),
+		SubnetId:                       aws.String(options.Subnet.Id),
+		AssociatePublicIpAddress:       t.AssociatePublicIpAddress,
+		DeleteOnTermination:            t.DeleteOnTermination,
+		Description:                    t.Description,
+		Groups:                         t.Groups,
+		InterfaceType:                  t.InterfaceType,
+		Ipv6AddressCount:               t.Ipv6AddressCount,
+		SecondaryPrivateIpAddressCount: t.SecondaryPrivateIpAddressCount,
+		NetworkCardIndex:               t.NetworkCardIndex,
+	}}
+	return nil
+}
+
+// 보안 그룹은 VPC 에 속하므로 복제 시 다른 VPC 의 서브넷은 지정할 수 없음
+func CheckCloneSubnet(source *Target, subnet *Subnet) error {
+	if source == nil || subnet == nil || source.VpcId == subnet.VpcId {
+		return nil
+	}
+	return fmt.Errorf("subnet %s is in %s but the security groups of %s belong to %s; clone within the same VPC or launch from a template", subnet.Id, subnet.VpcId, source.Id, source.VpcId)
+}
+
+// 원본 인스턴스의 AMI, 타입, 키, 서브넷, 보안 그룹, IAM 프로파일, 메타데이터 옵션, 볼륨 구성, 사용자 데이터, 사용자 태그 복사
+func cloneInstanceConfig(ctx context.Context, client *ec2.Client, instanceId string, input *ec2.RunInstancesInput, tags map[string]string) error {
+	output, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceId}})
+	if err != nil {
+		return err
+	}
+	if len(output.Reservations) == 0 || len(output.Reservations[0].Instances) == 0 {
+		return fmt.Errorf("instance %s not found", instanceId)
+	}
+	instance := output.Reservations[0].Instances[0]
+
+	input.ImageId = instance.ImageId
+	input.InstanceType = instance.InstanceType
+	input.KeyName = instance.KeyName
+	input.SubnetId = instance.SubnetId
+	input.EbsOptimized = instance.EbsOptimized
+	for _, group := range instance.SecurityGroups {
+		input.SecurityGroupIds = append(input.SecurityGroupIds, aws.ToString(group.GroupId))
+	}
+	if instance.IamInstanceProfile != nil {
+		input.IamInstanceProfile = &types.IamInstanceProfileSpecification{Arn: instance.IamInstanceProfile.Arn}
+	}
+	if instance.MetadataOptions != nil {
+		input.MetadataOptions = &types.InstanceMetadataOptionsRequest{
+			HttpTokens:              instance.MetadataOptions.HttpTokens,
+			HttpEndpoint:            instance.MetadataOptions.HttpEndpoint,
+			HttpPutResponseHopLimit: instance.MetadataOptions.HttpPutResponseHopLimit,
+		}
+	}
+	if instance.Monitoring != nil {
+		input.Monitoring = &types.RunInstancesMonitoringEnabled{Enabled: aws.Bool(instance.Monitoring.State == types.MonitoringStateEnabled)}
+	}
+
+	input.BlockDeviceMappings, err = cloneBlockDeviceMappings(ctx, client, instance.BlockDeviceMappings)
+	if err != nil {
+		return err
+	}
+
+	// 이미 base64 로 인코딩된 값
+	userData, err := client.DescribeInstanceAttribute(ctx, &ec2.DescribeInstanceAttributeInput{
+		InstanceId: aws.String(instanceId),
+		Attribute:  types.InstanceAttributeNameUserData,
+	})
+	if err != nil {
+		return fmt.Errorf("user data: %w", err)
+	}
+	if userData.UserData != nil && aws.ToString(userData.UserData.Value) != "" {
+		input.UserData = userData.UserData.Value
+	}
+
+	for _, tag := range instance.Tags {
+		key := aws.ToString(tag.Key)
+		// aws: 태그는 지정할 수 없고, 이름과 TTL 은 새로 정함
+		if strings.HasPrefix(key, "aws:") || key == "Name" || key == TTLExpiresTag || key == TTLActionTag {
+			continue
+		}
+		tags[key] = aws.ToString(tag.Value)
+	}
+	return nil
+}
+
+// 원본 EBS 볼륨의 크기, 타입, IOPS, 처리량, 암호화, 종료 시 삭제 여부로 매핑 생성 (내용은 AMI 스냅샷 기준, 추가 볼륨은 빈 볼륨)
+func cloneBlockDeviceMappings(ctx context.Context, client *ec2.Client, mappings []types.InstanceBlockDeviceMapping) ([]types.BlockDeviceMapping, error) {
+	var volumeIds []string
+	for _, mapping := range mappings {
+		if mapping.Ebs != nil {
+			volumeIds = append(volumeIds, aws.ToString(mapping.Ebs.VolumeId))
+		}
+	}
+	if len(volumeIds) == 0 {
+		return nil, nil
+	}
+
+	output, err := client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{VolumeIds: volumeIds})
+	if err != nil {
+		return nil, fmt.Errorf("volumes: %w", err)
+	}
+	volumes := make(map[string]types.Volume, len(output.Volumes))
+	for _, volume := range output.Volumes {
+		volumes[aws.ToString(volume.VolumeId)] = volume
+	}
+
+	var result []types.BlockDeviceMapping
+	for _, mapping := range mappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		volume, ok := volumes[aws.ToString(mapping.Ebs.VolumeId)]
+		if !ok {
+			return nil, fmt.Errorf("volume %s not found", aws.ToString(mapping.Ebs.VolumeId))
+		}
+		ebs := &types.EbsBlockDevice{
+			VolumeSize:          volume.Size,
+			VolumeType:          volume.VolumeType,
+			DeleteOnTermination: mapping.Ebs.DeleteOnTermination,
+		}
+		switch volume.VolumeType {
+		case types.VolumeTypeIo1, types.VolumeTypeIo2, types.VolumeTypeGp3:
+			ebs.Iops = volume.Iops
+		}
+		if volume.VolumeType == types.VolumeTypeGp3 {
+			ebs.Throughput = volume.Throughput
+		}
+		// 암호화된 스냅샷은 해제할 수 없으므로 암호화된 경우만 지정
+		if aws.ToBool(volume.Encrypted) {
+			ebs.Encrypted = aws.Bool(true)
+			ebs.KmsKeyId = volume.KmsKeyId
+		}
+		result = append(result, types.BlockDeviceMapping{DeviceName: mapping.DeviceName, Ebs: ebs})
+	}
+	return result, nil
+}
+
+// running 후 상태 검사 통과까지 대기, 최신 정보로 Target 반환
+func WaitInstanceReady(ctx context.Context, cfg aws.Config, instanceId string, timeout time.Duration, progress func(step string)) (*Target, error) {
+	client := ec2.NewFromConfig(cfg)
+	ids := []string{instanceId}
+
+	progress("waiting for running")
+	if err := ec2.NewInstanceRunningWaiter(client).Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids}, timeout); err != nil {
+		return nil, err
+	}
+	progress("waiting for status checks")
+	if err := ec2.NewInstanceStatusOkWaiter(client).Wait(ctx, &ec2.DescribeInstanceStatusInput{InstanceIds: ids}, timeout); err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids})
+	if err != nil {
+		return nil, err
+	}
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			return newTarget(instance), nil
+		}
+	}
+	return nil, fmt.Errorf("instance %s not found", instanceId)
+}
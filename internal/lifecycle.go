@@ -0,0 +1,263 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+type (
+	InstanceAction string
+
+	InstanceActionResult struct {
+		Target        *Target
+		Action        InstanceAction
+		PreviousState string
+		CurrentState  string
+		Status        string
+		Err           error
+		// 성공했지만 확인하지 못한 부분 등 참고 사항
+		Note     string
+		Duration time.Duration
+	}
+)
+
+const (
+	InstanceActionStart     InstanceAction = "start"
+	InstanceActionStop      InstanceAction = "stop"
+	InstanceActionReboot    InstanceAction = "reboot"
+	InstanceActionHibernate InstanceAction = "hibernate"
+	InstanceActionTerminate InstanceAction = "terminate"
+
+	ActionStatusSuccess = "success"
+	ActionStatusSkipped = "skipped"
+	ActionStatusFailed  = "failed"
+)
+
+var (
+	ErrInstanceProtected = errors.New("instance is protected")
+
+	// 재부팅 후 상태 검사가 ok 를 벗어나는지 지켜보는 시간과 주기
+	rebootDetectWindow = 2 * time.Minute
+	rebootPollInterval = 5 * time.Second
+)
+
+// 액션을 실행할 수 있는 인스턴스 상태
+func (a InstanceAction) SourceStates() []string {
+	switch a {
+	case InstanceActionStart:
+		return []string{"stopped"}
+	case InstanceActionStop, InstanceActionHibernate:
+		return []string{"pending", "running"}
+	case InstanceActionReboot:
+		return []string{"running"}
+	case InstanceActionTerminate:
+		return []string{"pending", "running", "stopping", "stopped"}
+	default:
+		return nil
+	}
+}
+
+// 액션 완료 후 기대하는 인스턴스 상태
+func (a InstanceAction) TargetState() string {
+	switch a {
+	case InstanceActionStart, InstanceActionReboot:
+		return "running"
+	case InstanceActionStop, InstanceActionHibernate:
+		return "stopped"
+	case InstanceActionTerminate:
+		return "terminated"
+	default:
+		return ""
+	}
+}
+
+// 여러 인스턴스에 동시에 액션 실행 (인스턴스별 결과 반환)
+func RunInstanceActions(ctx context.Context, cfg aws.Config, action InstanceAction, targets []*Target, concurrency int, waitTimeout time.Duration) []InstanceActionResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make([]InstanceActionResult, len(targets))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target *Target) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = RunInstanceAction(ctx, cfg, action, target, waitTimeout)
+		}(i, target)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// 단일 인스턴스에 액션 실행 후 목표 상태까지 대기
+func RunInstanceAction(ctx context.Context, cfg aws.Config, action InstanceAction, target *Target, waitTimeout time.Duration) InstanceActionResult {
+	client := ec2.NewFromConfig(cfg)
+	started := time.Now()
+	result := InstanceActionResult{
+		Target:        target,
+		Action:        action,
+		PreviousState: target.State,
+	}
+
+	finish := func(err error) InstanceActionResult {
+		result.Duration = time.Since(started)
+		result.Err = err
+		switch {
+		case err == nil:
+			result.Status = ActionStatusSuccess
+		case errors.Is(err, ErrInstanceProtected):
+			result.Status = ActionStatusSkipped
+		default:
+			result.Status = ActionStatusFailed
+		}
+		if state, stateErr := describeInstanceState(ctx, client, target.Id); stateErr == nil {
+			result.CurrentState = state
+		}
+		return result
+	}
+
+	if err := checkInstanceProtection(ctx, client, target.Id, action); err != nil {
+		return finish(err)
+	}
+
+	ids := []string{target.Id}
+	var err error
+	switch action {
+	case InstanceActionStart:
+		if _, err = client.StartInstances(ctx, &ec2.StartInstancesInput{InstanceIds: ids}); err == nil {
+			err = ec2.NewInstanceRunningWaiter(client).Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids}, waitTimeout)
+		}
+	case InstanceActionStop, InstanceActionHibernate:
+		input := &ec2.StopInstancesInput{InstanceIds: ids}
+		if action == InstanceActionHibernate {
+			input.Hibernate = aws.Bool(true)
+		}
+		if _, err = client.StopInstances(ctx, input); err == nil {
+			err = ec2.NewInstanceStoppedWaiter(client).Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids}, waitTimeout)
+		}
+	case InstanceActionReboot:
+		if _, err = client.RebootInstances(ctx, &ec2.RebootInstancesInput{InstanceIds: ids}); err == nil {
+			result.Note, err = waitRebooted(ctx, client, target.Id, waitTimeout)
+		}
+	case InstanceActionTerminate:
+		if _, err = client.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: ids}); err == nil {
+			err = ec2.NewInstanceTerminatedWaiter(client).Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids}, waitTimeout)
+		}
+	default:
+		err = fmt.Errorf("unknown instance action: %s", action)
+	}
+
+	return finish(err)
+}
+
+// 재부팅 직후에는 상태 검사가 아직 이전의 ok 이므로, ok 를 벗어난 뒤 다시 ok 가 될 때까지 대기
+// 짧은 재부팅은 상태 검사에 드러나지 않을 수 있어 그 경우 완료를 확인하지 못했다고 표시
+func waitRebooted(ctx context.Context, client *ec2.Client, instanceId string, timeout time.Duration) (string, error) {
+	window := min(rebootDetectWindow, timeout)
+	deadline := time.Now().Add(window)
+	for {
+		status, err := describeInstanceStatus(ctx, client, instanceId)
+		if err != nil {
+			return "", err
+		}
+		if status != types.SummaryStatusOk {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Sprintf("reboot requested; status checks stayed ok for %s, completion not confirmed", window), nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(rebootPollInterval):
+		}
+	}
+
+	err := ec2.NewInstanceStatusOkWaiter(client).Wait(ctx, &ec2.DescribeInstanceStatusInput{InstanceIds: []string{instanceId}}, timeout)
+	return "", err
+}
+
+func describeInstanceStatus(ctx context.Context, client *ec2.Client, instanceId string) (types.SummaryStatus, error) {
+	output, err := client.DescribeInstanceStatus(ctx, &ec2.DescribeInstanceStatusInput{
+		InstanceIds:         []string{instanceId},
+		IncludeAllInstances: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, status := range output.InstanceStatuses {
+		if status.InstanceStatus != nil {
+			return status.InstanceStatus.Status, nil
+		}
+	}
+	return "", nil
+}
+
+// 종료 방지(disableApiTermination), 중지 방지(disableApiStop) 설정 확인
+func checkInstanceProtection(ctx context.Context, client *ec2.Client, instanceId string, action InstanceAction) error {
+	var attribute types.InstanceAttributeName
+	switch action {
+	case InstanceActionTerminate:
+		attribute = types.InstanceAttributeNameDisableApiTermination
+	case InstanceActionStop, InstanceActionHibernate:
+		attribute = types.InstanceAttributeNameDisableApiStop
+	default:
+		return nil
+	}
+
+	output, err := client.DescribeInstanceAttribute(ctx, &ec2.DescribeInstanceAttributeInput{
+		InstanceId: aws.String(instanceId),
+		Attribute:  attribute,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.DisableApiTermination != nil && aws.ToBool(output.DisableApiTermination.Value) {
+		return fmt.Errorf("%w: termination protection is enabled", ErrInstanceProtected)
+	}
+	if output.DisableApiStop != nil && aws.ToBool(output.DisableApiStop.Value) {
+		return fmt.Errorf("%w: stop protection is enabled", ErrInstanceProtected)
+	}
+	return nil
+}
+
+func describeInstanceState(ctx context.Context, client *ec2.Client, instanceId string) (string, error) {
+	output, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceId},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			if instance.State != nil {
+				return string(instance.State.Name), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("instance %s not found", instanceId)
+}
+
+func PrintInstanceActionResult(cmd string, result InstanceActionResult) {
+	message := result.Note
+	if result.Err != nil {
+		message = result.Err.Error()
+	}
+	LogInstanceAction(cmd, string(result.Action), result.Target.Id, result.Target.Name,
+		result.PreviousState, result.CurrentState, result.Status, result.Duration.Round(time.Second).String(), message)
+}
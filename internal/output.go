@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputCSV   = "csv"
+)
+
+var OutputFormats = []string{OutputTable, OutputJSON, OutputCSV}
+
+func ValidateOutputFormat(format string) error {
+	for _, f := range OutputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q (%s)", format, strings.Join(OutputFormats, ", "))
+}
+
+// table/csv 는 headers+rows, json 은 value 를 그대로 출력
+func PrintOutput(format string, headers []string, rows [][]string, value interface{}) error {
+	switch format {
+	case OutputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case OutputCSV:
+		writer := csv.NewWriter(os.Stdout)
+		if err := writer.Write(headers); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case OutputTable, "":
+		LogTable(headers, rows)
+		return nil
+	default:
+		return ValidateOutputFormat(format)
+	}
+}
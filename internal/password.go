@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	RDPPort          = 3389
+	WindowsAdminUser = "Administrator"
+)
+
+var (
+	ErrPasswordNotReady = errors.New("password is not available yet (Windows generates it a few minutes after launch)")
+)
+
+// pem 키 경로 (기본 ~/.ssh/<KeyName>.pem)
+func InstanceKeyPath(target *Target) string {
+	return fmt.Sprintf("%s/.ssh/%s.pem", FindHomeFolder(), target.KeyName)
+}
+
+// GetPasswordData 로 받은 암호문을 인스턴스 키(RSA PKCS#1 v1.5) 로 복호화
+func GetWindowsPassword(ctx context.Context, cfg aws.Config, target *Target, keyPath string) (string, error) {
+	output, err := ec2.NewFromConfig(cfg).GetPasswordData(ctx, &ec2.GetPasswordDataInput{
+		InstanceId: aws.String(target.Id),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data := strings.TrimSpace(aws.ToString(output.PasswordData))
+	if data == "" {
+		return "", ErrPasswordNotReady
+	}
+	encrypted, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", fmt.Errorf("decode password data: %w", err)
+	}
+
+	key, err := loadRSAPrivateKey(keyPath)
+	if err != nil {
+		return "", err
+	}
+	password, err := rsa.DecryptPKCS1v15(rand.Reader, key, encrypted)
+	if err != nil {
+		return "", fmt.Errorf("decrypt password with %s (is it the launch key pair?): %w", keyPath, err)
+	}
+	return string(password), nil
+}
+
+// 암호가 걸린 키는 입력 받음
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ssh.ParseRawPrivateKey(pem)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		var passphrase string
+		if err := survey.AskOne(&survey.Password{Message: fmt.Sprintf("Passphrase for %s:", path)}, &passphrase); err != nil {
+			return nil, err
+		}
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(pem, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: Windows passwords can only be decrypted with an RSA key", path)
+	}
+	return rsaKey, nil
+}
+
+// OS 클립보드 명령으로 복사 (macOS pbcopy, Windows clip, Linux wl-copy/xclip/xsel)
+func CopyToClipboard(text string) error {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		candidates = [][]string{{"wl-copy"}, {"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(candidate[0], candidate[1:]...)
+		cmd.Stdin = bytes.NewBufferString(text)
+		return cmd.Run()
+	}
+
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		names = append(names, candidate[0])
+	}
+	return fmt.Errorf("no clipboard command found (%s)", strings.Join(names, ", "))
+}
+
+// RDP 접속 정보 (퍼블릭 IP 가 없으면 터널 사용 안내)
+func PrintRDPDetails(target *Target, password string) {
+	host := target.PublicIp
+	if host == "" {
+		host = target.PrivateIp
+	}
+
+	attributes := [][2]string{
+		{"Host", fmt.Sprintf("%s:%d", host, RDPPort)},
+		{"User", WindowsAdminUser},
+	}
+	if password != "" {
+		attributes = append(attributes, [2]string{"Password", password})
+	}
+	LogSection("RDP")
+	LogAttributes(attributes)
+
+	if target.PublicIp == "" {
+		LogInfo("퍼블릭 IP 가 없습니다. bastion 터널로 접속하세요: mcl tunnel -L %d:%s:%d 후 localhost:%d", RDPPort+10000, target.PrivateIp, RDPPort, RDPPort+10000)
+	}
+}
@@ -7,15 +7,18 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
 type (
 	RdsTarget struct {
-		Name     string
-		Endpoint string
-		Id       string
-		Status   string
-		Engine   string
+		Name           string
+		Endpoint       string
+		Id             string
+		Status         string
+		Engine         string
+		Port           int32
+		SecurityGroups []string
 	}
 )
 
@@ -34,31 +37,50 @@ func FindRdsInstance(ctx context.Context, cfg aws.Config) (map[string]*RdsTarget
 		}
 
 		for _, dbInstance := range output.DBInstances {
-			var name string
-			for _, tag := range dbInstance.TagList {
-				if aws.ToString(tag.Key) == "Name" {
-					name = aws.ToString(tag.Value)
-					break
-				}
-			}
-			if name == "" {
-				name = aws.ToString(dbInstance.DBInstanceIdentifier)
-			}
-
-			instanceId := aws.ToString(dbInstance.DBInstanceIdentifier)
-			table[instanceId] = &RdsTarget{
-				Name:     name,
-				Endpoint: aws.ToString(dbInstance.Endpoint.Address),
-				Id:       instanceId,
-				Status:   aws.ToString(dbInstance.DBInstanceStatus),
-				Engine:   aws.ToString(dbInstance.Engine),
-			}
+			target := newRdsTarget(dbInstance)
+			table[target.Id] = target
 		}
 	}
 
 	return table, nil
 }
 
+func newRdsTarget(dbInstance types.DBInstance) *RdsTarget {
+	var name string
+	for _, tag := range dbInstance.TagList {
+		if aws.ToString(tag.Key) == "Name" {
+			name = aws.ToString(tag.Value)
+			break
+		}
+	}
+	if name == "" {
+		name = aws.ToString(dbInstance.DBInstanceIdentifier)
+	}
+
+	// 생성 중인 인스턴스는 Endpoint 가 없음
+	var endpoint string
+	var port int32
+	if dbInstance.Endpoint != nil {
+		endpoint = aws.ToString(dbInstance.Endpoint.Address)
+		port = aws.ToInt32(dbInstance.Endpoint.Port)
+	}
+
+	securityGroups := make([]string, 0, len(dbInstance.VpcSecurityGroups))
+	for _, group := range dbInstance.VpcSecurityGroups {
+		securityGroups = append(securityGroups, aws.ToString(group.VpcSecurityGroupId))
+	}
+
+	return &RdsTarget{
+		Name:           name,
+		Endpoint:       endpoint,
+		Id:             aws.ToString(dbInstance.DBInstanceIdentifier),
+		Status:         aws.ToString(dbInstance.DBInstanceStatus),
+		Engine:         aws.ToString(dbInstance.Engine),
+		Port:           port,
+		SecurityGroups: securityGroups,
+	}
+}
+
 func FindDBInstancesIds(ctx context.Context, cfg aws.Config) ([]string, error) {
 	table, err := FindRdsInstance(ctx, cfg)
 	if err != nil {
@@ -136,25 +158,8 @@ func FindRdsInstanceWithPaging(ctx context.Context, cfg aws.Config, page int) (m
 		}
 
 		for _, dbInstance := range output.DBInstances {
-			var name string
-			for _, tag := range dbInstance.TagList {
-				if aws.ToString(tag.Key) == "Name" {
-					name = aws.ToString(tag.Value)
-					break
-				}
-			}
-			if name == "" {
-				name = aws.ToString(dbInstance.DBInstanceIdentifier)
-			}
-
-			instanceId := aws.ToString(dbInstance.DBInstanceIdentifier)
-			table[instanceId] = &RdsTarget{
-				Name:     name,
-				Endpoint: aws.ToString(dbInstance.Endpoint.Address),
-				Id:       instanceId,
-				Status:   aws.ToString(dbInstance.DBInstanceStatus),
-				Engine:   aws.ToString(dbInstance.Engine),
-			}
+			target := newRdsTarget(dbInstance)
+			table[target.Id] = target
 		}
 	}
 
@@ -0,0 +1,289 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+type (
+	ResizeOptions struct {
+		InstanceType string
+		// 타입 변경 전 AMI 생성 (available 까지 대기)
+		CreateImage  bool
+		ImageTimeout time.Duration
+		WaitTimeout  time.Duration
+	}
+
+	// 변경 가능 여부 검사 결과 (Errors 가 있으면 변경 불가, Warnings 는 확인 후 진행)
+	ResizeCheck struct {
+		Target   *Target
+		Errors   []string
+		Warnings []string
+	}
+)
+
+// 아키텍처, ENA, NVMe 호환성과 AZ 제공 여부 검사
+func CheckResize(ctx context.Context, cfg aws.Config, targets []*Target, instanceType string) ([]ResizeCheck, error) {
+	client := ec2.NewFromConfig(cfg)
+
+	typeNames := []types.InstanceType{types.InstanceType(instanceType)}
+	for _, target := range targets {
+		typeNames = append(typeNames, types.InstanceType(target.InstanceType))
+	}
+	typeInfo, err := describeInstanceTypes(ctx, client, typeNames)
+	if err != nil {
+		return nil, err
+	}
+	newType, ok := typeInfo[instanceType]
+	if !ok {
+		return nil, fmt.Errorf("unknown instance type %s", instanceType)
+	}
+
+	zones, err := instanceTypeZones(ctx, client, instanceType)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.Id)
+	}
+	instances := make(map[string]types.Instance)
+	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{InstanceIds: ids})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				instances[aws.ToString(instance.InstanceId)] = instance
+			}
+		}
+	}
+
+	checks := make([]ResizeCheck, 0, len(targets))
+	for _, target := range targets {
+		check := ResizeCheck{Target: target}
+		instance, found := instances[target.Id]
+		currentType, known := typeInfo[target.InstanceType]
+		switch {
+		case !found:
+			check.Errors = append(check.Errors, "instance not found")
+		case target.InstanceType == instanceType:
+			check.Errors = append(check.Errors, fmt.Sprintf("already %s", instanceType))
+		default:
+			check.Errors, check.Warnings = resizeCompatibility(instance, currentType, known, newType, zones)
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func resizeCompatibility(instance types.Instance, currentType types.InstanceTypeInfo, currentKnown bool, newType types.InstanceTypeInfo, zones map[string]struct{}) ([]string, []string) {
+	var errs, warnings []string
+
+	architecture := instance.Architecture
+	supported := false
+	var architectures []string
+	if newType.ProcessorInfo != nil {
+		for _, a := range newType.ProcessorInfo.SupportedArchitectures {
+			architectures = append(architectures, string(a))
+			if string(a) == string(architecture) {
+				supported = true
+			}
+		}
+	}
+	if !supported {
+		errs = append(errs, fmt.Sprintf("architecture %s is not supported by %s (%s)", architecture, newType.InstanceType, strings.Join(architectures, ", ")))
+	}
+
+	if newType.NetworkInfo != nil && newType.NetworkInfo.EnaSupport == types.EnaSupportRequired && !aws.ToBool(instance.EnaSupport) {
+		errs = append(errs, fmt.Sprintf("%s requires ENA but enaSupport is disabled on the instance", newType.InstanceType))
+	}
+
+	// Xen → Nitro 처럼 NVMe 가 새로 필요하면 OS 에 NVMe 드라이버가 있어야 부팅됨
+	if newType.EbsInfo != nil && newType.EbsInfo.NvmeSupport == types.EbsNvmeSupportRequired {
+		if currentKnown && currentType.EbsInfo != nil && currentType.EbsInfo.NvmeSupport == types.EbsNvmeSupportUnsupported {
+			warnings = append(warnings, fmt.Sprintf("%s exposes EBS as NVMe; make sure the OS has NVMe drivers and fstab does not use /dev/xvd* names", newType.InstanceType))
+		}
+	}
+
+	zone := ""
+	if instance.Placement != nil {
+		zone = aws.ToString(instance.Placement.AvailabilityZone)
+	}
+	if _, ok := zones[zone]; !ok {
+		errs = append(errs, fmt.Sprintf("%s is not offered in %s", newType.InstanceType, zone))
+	}
+
+	if instance.RootDeviceType == types.DeviceTypeInstanceStore {
+		errs = append(errs, "instance store-backed instances cannot be stopped")
+	}
+	return errs, warnings
+}
+
+func describeInstanceTypes(ctx context.Context, client *ec2.Client, names []types.InstanceType) (map[string]types.InstanceTypeInfo, error) {
+	unique := make(map[types.InstanceType]struct{})
+	var query []types.InstanceType
+	for _, name := range names {
+		if _, ok := unique[name]; ok || name == "" {
+			continue
+		}
+		unique[name] = struct{}{}
+		query = append(query, name)
+	}
+
+	info := make(map[string]types.InstanceTypeInfo)
+	// 잘못된 타입이 하나라도 있으면 전체 요청이 실패하므로 하나씩 조회
+	for _, name := range query {
+		output, err := client.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{InstanceTypes: []types.InstanceType{name}})
+		if err != nil {
+			if strings.Contains(err.Error(), "InvalidInstanceType") {
+				continue
+			}
+			return nil, err
+		}
+		for _, t := range output.InstanceTypes {
+			info[string(t.InstanceType)] = t
+		}
+	}
+	return info, nil
+}
+
+// 인스턴스 타입을 제공하는 AZ 목록
+func instanceTypeZones(ctx context.Context, client *ec2.Client, instanceType string) (map[string]struct{}, error) {
+	zones := make(map[string]struct{})
+	paginator := ec2.NewDescribeInstanceTypeOfferingsPaginator(client, &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: types.LocationTypeAvailabilityZone,
+		Filters:      []types.Filter{{Name: aws.String("instance-type"), Values: []string{instanceType}}},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, offering := range output.InstanceTypeOfferings {
+			zones[aws.ToString(offering.Location)] = struct{}{}
+		}
+	}
+	return zones, nil
+}
+
+// 정지 → (AMI) → 타입 변경 → 시작 → 상태 검사 대기, 시작 실패 시 원래 타입으로 되돌림
+// 원래 정지 상태였던 인스턴스는 타입만 변경하고 정지 상태로 둠
+// AMI 는 실행 중에 만들면 재부팅되거나(일관성) 파일 시스템이 불완전할 수 있어(no-reboot) 정지 후 생성하고,
+// 복구용으로 쓸 수 있도록 available 이 된 뒤 타입을 변경. 실패 시 에러에 AMI ID 포함
+func ResizeInstance(ctx context.Context, cfg aws.Config, target *Target, options ResizeOptions, progress func(step string)) (string, error) {
+	client := ec2.NewFromConfig(cfg)
+	ids := []string{target.Id}
+	originalType := target.InstanceType
+	wasRunning := target.State == "running" || target.State == "pending"
+
+	if wasRunning {
+		progress("stopping")
+		if result := RunInstanceAction(ctx, cfg, InstanceActionStop, target, options.WaitTimeout); result.Err != nil {
+			return "", fmt.Errorf("stop: %w", result.Err)
+		}
+	}
+
+	var imageId string
+	withImage := func(err error) error {
+		if imageId == "" {
+			return err
+		}
+		return fmt.Errorf("%w (pre-resize ami: %s)", err, imageId)
+	}
+
+	if options.CreateImage {
+		progress("creating AMI")
+		var err error
+		imageId, err = CreateImage(ctx, cfg, target, AmiOptions{
+			Name:        fmt.Sprintf("%s-pre-resize", DefaultAmiName(target)),
+			Description: fmt.Sprintf("before resize %s -> %s", originalType, options.InstanceType),
+			NoReboot:    true,
+		})
+		if err != nil {
+			return "", restartAfterFailure(ctx, cfg, target, wasRunning, options.WaitTimeout, fmt.Errorf("create AMI: %w", err))
+		}
+		progress(fmt.Sprintf("waiting for AMI %s", imageId))
+		if err := WaitImageAvailable(ctx, cfg, imageId, options.ImageTimeout); err != nil {
+			return "", withImage(restartAfterFailure(ctx, cfg, target, wasRunning, options.WaitTimeout, fmt.Errorf("wait for AMI: %w", err)))
+		}
+	}
+
+	progress(fmt.Sprintf("modifying %s -> %s", originalType, options.InstanceType))
+	if err := modifyInstanceType(ctx, client, target.Id, options.InstanceType); err != nil {
+		return "", withImage(restartAfterFailure(ctx, cfg, target, wasRunning, options.WaitTimeout, fmt.Errorf("modify: %w", err)))
+	}
+	target.InstanceType = options.InstanceType
+
+	if !wasRunning {
+		return resizeSummary(originalType, options.InstanceType, imageId, "left stopped"), nil
+	}
+
+	progress("starting")
+	startErr := startAndWaitStatusOk(ctx, client, ids, options.WaitTimeout)
+	if startErr == nil {
+		return resizeSummary(originalType, options.InstanceType, imageId, ""), nil
+	}
+
+	// 용량 부족, 상태 검사 실패 등 → 원래 타입으로 복구 후 다시 시작
+	progress(fmt.Sprintf("start failed (%v), rolling back to %s", startErr, originalType))
+	if result := RunInstanceAction(ctx, cfg, InstanceActionStop, target, options.WaitTimeout); result.Err != nil {
+		return "", withImage(fmt.Errorf("start: %v; rollback: stop: %w", startErr, result.Err))
+	}
+	if err := modifyInstanceType(ctx, client, target.Id, originalType); err != nil {
+		return "", withImage(fmt.Errorf("start: %v; rollback: modify: %w", startErr, err))
+	}
+	target.InstanceType = originalType
+	if err := startAndWaitStatusOk(ctx, client, ids, options.WaitTimeout); err != nil {
+		return "", withImage(fmt.Errorf("start: %v; rollback: start %s: %w", startErr, originalType, err))
+	}
+	return "", withImage(fmt.Errorf("start on %s failed, rolled back to %s: %w", options.InstanceType, originalType, startErr))
+}
+
+func restartAfterFailure(ctx context.Context, cfg aws.Config, target *Target, wasRunning bool, timeout time.Duration, cause error) error {
+	if !wasRunning {
+		return cause
+	}
+	if err := startAndWaitStatusOk(ctx, ec2.NewFromConfig(cfg), []string{target.Id}, timeout); err != nil {
+		return fmt.Errorf("%v; restart: %w", cause, err)
+	}
+	return cause
+}
+
+func modifyInstanceType(ctx context.Context, client *ec2.Client, instanceId, instanceType string) error {
+	_, err := client.ModifyInstanceAttribute(ctx, &ec2.ModifyInstanceAttributeInput{
+		InstanceId:   aws.String(instanceId),
+		InstanceType: &types.AttributeValue{Value: aws.String(instanceType)},
+	})
+	return err
+}
+
+func startAndWaitStatusOk(ctx context.Context, client *ec2.Client, ids []string, timeout time.Duration) error {
+	if _, err := client.StartInstances(ctx, &ec2.StartInstancesInput{InstanceIds: ids}); err != nil {
+		return err
+	}
+	if err := ec2.NewInstanceRunningWaiter(client).Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids}, timeout); err != nil {
+		return err
+	}
+	return ec2.NewInstanceStatusOkWaiter(client).Wait(ctx, &ec2.DescribeInstanceStatusInput{InstanceIds: ids}, timeout)
+}
+
+func resizeSummary(from, to, imageId, note string) string {
+	summary := fmt.Sprintf("%s -> %s", from, to)
+	if imageId != "" {
+		summary += fmt.Sprintf(", ami: %s", imageId)
+	}
+	if note != "" {
+		summary += ", " + note
+	}
+	return summary
+}
@@ -0,0 +1,360 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	RightsizeIdle            = "idle"
+	RightsizeUnderutilized   = "underutilized"
+	RightsizeHealthy         = "healthy"
+	RightsizeNoData          = "no data"
+	rightsizeHoursPerMonth   = 730
+	rightsizeMetricPeriod    = 3600
+	rightsizeMaxQueries      = 500
+	rightsizeMemoryMetric    = "mem_used_percent"
+	rightsizeWinMemoryMetric = "Memory % Committed Bytes In Use"
+)
+
+var (
+	// 크기별 정규화 계수 (large = 4)
+	instanceSizeFactors = []struct {
+		Size   string
+		Factor float64
+	}{
+		{"nano", 0.25}, {"micro", 0.5}, {"small", 1}, {"medium", 2}, {"large", 4},
+		{"xlarge", 8}, {"2xlarge", 16}, {"4xlarge", 32}, {"8xlarge", 64},
+		{"12xlarge", 96}, {"16xlarge", 128}, {"24xlarge", 192},
+	}
+
+	// 패밀리별 large 크기 시간당 온디맨드 가격 (USD, us-east-1, Linux 기준 추정치)
+	instanceFamilyPrices = map[string]float64{
+		"t2": 0.0928, "t3": 0.0832, "t3a": 0.0752, "t4g": 0.0672,
+		"m5": 0.096, "m5a": 0.086, "m6i": 0.096, "m6a": 0.0864, "m6g": 0.077, "m7i": 0.1008, "m7g": 0.0816,
+		"c5": 0.085, "c5a": 0.077, "c6i": 0.085, "c6a": 0.0765, "c6g": 0.068, "c7i": 0.08925, "c7g": 0.0725,
+		"r5": 0.126, "r5a": 0.113, "r6i": 0.126, "r6a": 0.1134, "r6g": 0.1008, "r7i": 0.1323, "r7g": 0.1071,
+	}
+)
+
+type (
+	RightsizeOptions struct {
+		Window time.Duration
+		// 평균 CPU(%) 와 일 평균 네트워크(bytes) 가 모두 이하이면 idle
+		IdleCPU     float64
+		IdleNetwork float64
+		// 최대 CPU(%) 와 최대 메모리(%) 가 모두 이하이면 underutilized
+		UnderCPU    float64
+		UnderMemory float64
+	}
+
+	RightsizeResult struct {
+		Target         *Target  `json:"-"`
+		Id             string   `json:"id"`
+		Name           string   `json:"name"`
+		InstanceType   string   `json:"instanceType"`
+		Lifecycle      string   `json:"lifecycle"`
+		CPUAverage     *float64 `json:"cpuAverage"`
+		CPUPeak        *float64 `json:"cpuPeak"`
+		MemoryPeak     *float64 `json:"memoryPeak"`
+		NetworkPerDay  *float64 `json:"networkBytesPerDay"`
+		Class          string   `json:"class"`
+		Suggestion     string   `json:"suggestion"`
+		MonthlyCost    *float64 `json:"monthlyCost"`
+		MonthlySavings *float64 `json:"monthlySavings"`
+	}
+
+	rightsizeSeries struct {
+		cpu, networkIn, networkOut, memory []float64
+	}
+)
+
+// 인스턴스 타입의 시간당 온디맨드 가격 (가격표에 없으면 false)
+func InstanceHourlyPrice(instanceType string) (float64, bool) {
+	family, size, ok := strings.Cut(instanceType, ".")
+	if !ok {
+		return 0, false
+	}
+	price, ok := instanceFamilyPrices[family]
+	if !ok {
+		return 0, false
+	}
+	for _, s := range instanceSizeFactors {
+		if s.Size == size {
+			return price * s.Factor / 4, true
+		}
+	}
+	return 0, false
+}
+
+// 같은 패밀리에서 한 단계 작은 타입 (없으면 빈 문자열)
+func SmallerInstanceType(instanceType string) string {
+	family, size, ok := strings.Cut(instanceType, ".")
+	if !ok {
+		return ""
+	}
+	for i, s := range instanceSizeFactors {
+		if s.Size != size || i == 0 {
+			continue
+		}
+		if !instanceSizeExists(family, instanceSizeFactors[i-1].Size) {
+			return ""
+		}
+		return family + "." + instanceSizeFactors[i-1].Size
+	}
+	return ""
+}
+
+// t 계열은 nano 부터, Graviton(g) 은 medium 부터, 그 외는 large 부터 제공
+func instanceSizeExists(family, size string) bool {
+	minimum := "large"
+	switch {
+	case strings.HasPrefix(family, "t"):
+		minimum = "nano"
+	case strings.HasSuffix(family, "g"):
+		minimum = "medium"
+	}
+	for _, s := range instanceSizeFactors {
+		if s.Size == minimum {
+			return true
+		}
+		if s.Size == size {
+			return false
+		}
+	}
+	return false
+}
+
+// CloudWatch 지표로 인스턴스를 idle / underutilized / healthy 로 분류
+func AnalyzeRightsizing(ctx context.Context, cfg aws.Config, targets []*Target, options RightsizeOptions) ([]*RightsizeResult, error) {
+	client := cloudwatch.NewFromConfig(cfg)
+
+	memoryMetrics, err := findMemoryMetrics(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []cwtypes.MetricDataQuery
+	for i, target := range targets {
+		dimensions := []cwtypes.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(target.Id)}}
+		queries = append(queries,
+			rightsizeQuery(fmt.Sprintf("cpu_%d", i), cwtypes.Metric{Namespace: aws.String("AWS/EC2"), MetricName: aws.String("CPUUtilization"), Dimensions: dimensions}, "Average"),
+			rightsizeQuery(fmt.Sprintf("netin_%d", i), cwtypes.Metric{Namespace: aws.String("AWS/EC2"), MetricName: aws.String("NetworkIn"), Dimensions: dimensions}, "Sum"),
+			rightsizeQuery(fmt.Sprintf("netout_%d", i), cwtypes.Metric{Namespace: aws.String("AWS/EC2"), MetricName: aws.String("NetworkOut"), Dimensions: dimensions}, "Sum"),
+		)
+		if metric, ok := memoryMetrics[target.Id]; ok {
+			queries = append(queries, rightsizeQuery(fmt.Sprintf("mem_%d", i), metric, "Average"))
+		}
+	}
+
+	end := time.Now().Truncate(time.Hour)
+	start := end.Add(-options.Window)
+	values := make(map[string][]float64)
+	for begin := 0; begin < len(queries); begin += rightsizeMaxQueries {
+		finish := begin + rightsizeMaxQueries
+		if finish > len(queries) {
+			finish = len(queries)
+		}
+
+		paginator := cloudwatch.NewGetMetricDataPaginator(client, &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries[begin:finish],
+			StartTime:         aws.Time(start),
+			EndTime:           aws.Time(end),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range output.MetricDataResults {
+				id := aws.ToString(result.Id)
+				values[id] = append(values[id], result.Values...)
+			}
+		}
+	}
+
+	days := options.Window.Hours() / 24
+	results := make([]*RightsizeResult, 0, len(targets))
+	for i, target := range targets {
+		series := rightsizeSeries{
+			cpu:        values[fmt.Sprintf("cpu_%d", i)],
+			networkIn:  values[fmt.Sprintf("netin_%d", i)],
+			networkOut: values[fmt.Sprintf("netout_%d", i)],
+			memory:     values[fmt.Sprintf("mem_%d", i)],
+		}
+		results = append(results, classifyRightsizing(target, series, days, options))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return rightsizeSavings(results[i]) > rightsizeSavings(results[j])
+	})
+	return results, nil
+}
+
+func rightsizeQuery(id string, metric cwtypes.Metric, stat string) cwtypes.MetricDataQuery {
+	return cwtypes.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &cwtypes.MetricStat{
+			Metric: &metric,
+			Period: aws.Int32(rightsizeMetricPeriod),
+			Stat:   aws.String(stat),
+		},
+		ReturnData: aws.Bool(true),
+	}
+}
+
+// CWAgent 메모리 지표 (인스턴스 ID → 지표, 추가 차원 포함)
+func findMemoryMetrics(ctx context.Context, client *cloudwatch.Client) (map[string]cwtypes.Metric, error) {
+	metrics := make(map[string]cwtypes.Metric)
+	for _, name := range []string{rightsizeMemoryMetric, rightsizeWinMemoryMetric} {
+		paginator := cloudwatch.NewListMetricsPaginator(client, &cloudwatch.ListMetricsInput{
+			Namespace:  aws.String("CWAgent"),
+			MetricName: aws.String(name),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, metric := range output.Metrics {
+				for _, dimension := range metric.Dimensions {
+					if aws.ToString(dimension.Name) != "InstanceId" {
+						continue
+					}
+					if _, ok := metrics[aws.ToString(dimension.Value)]; !ok {
+						metrics[aws.ToString(dimension.Value)] = metric
+					}
+				}
+			}
+		}
+	}
+	return metrics, nil
+}
+
+func classifyRightsizing(target *Target, series rightsizeSeries, days float64, options RightsizeOptions) *RightsizeResult {
+	result := &RightsizeResult{
+		Target:       target,
+		Id:           target.Id,
+		Name:         target.Name,
+		InstanceType: target.InstanceType,
+		Lifecycle:    target.Lifecycle,
+		Class:        RightsizeNoData,
+	}
+
+	onDemand := target.Lifecycle == "" || target.Lifecycle == lifecycleOnDemand
+	price, hasPrice := InstanceHourlyPrice(target.InstanceType)
+	if hasPrice && onDemand {
+		result.MonthlyCost = aws.Float64(price * rightsizeHoursPerMonth)
+	}
+
+	if len(series.cpu) == 0 {
+		return result
+	}
+	result.CPUAverage = aws.Float64(metricAverage(series.cpu))
+	result.CPUPeak = aws.Float64(metricMax(series.cpu))
+	if len(series.memory) > 0 {
+		result.MemoryPeak = aws.Float64(metricMax(series.memory))
+	}
+	if days > 0 {
+		result.NetworkPerDay = aws.Float64((metricSum(series.networkIn) + metricSum(series.networkOut)) / days)
+	}
+
+	network := aws.ToFloat64(result.NetworkPerDay)
+	memoryLow := result.MemoryPeak == nil || *result.MemoryPeak <= options.UnderMemory
+	switch {
+	case *result.CPUAverage <= options.IdleCPU && network <= options.IdleNetwork:
+		result.Class = RightsizeIdle
+		result.Suggestion = "stop or terminate"
+		if result.MonthlyCost != nil {
+			result.MonthlySavings = aws.Float64(*result.MonthlyCost)
+		}
+	case *result.CPUPeak <= options.UnderCPU && memoryLow:
+		result.Class = RightsizeUnderutilized
+		smaller := SmallerInstanceType(target.InstanceType)
+		if smaller == "" {
+			result.Suggestion = "no smaller size in family"
+			break
+		}
+		result.Suggestion = smaller
+		if smallerPrice, ok := InstanceHourlyPrice(smaller); ok && result.MonthlyCost != nil {
+			result.MonthlySavings = aws.Float64((price - smallerPrice) * rightsizeHoursPerMonth)
+		}
+	default:
+		result.Class = RightsizeHealthy
+	}
+	return result
+}
+
+func rightsizeSavings(result *RightsizeResult) float64 {
+	return aws.ToFloat64(result.MonthlySavings)
+}
+
+func metricSum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func metricAverage(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return metricSum(values) / float64(len(values))
+}
+
+func metricMax(values []float64) float64 {
+	peak := math.Inf(-1)
+	for _, v := range values {
+		peak = math.Max(peak, v)
+	}
+	return peak
+}
+
+func RightsizeTable(results []*RightsizeResult) ([]string, [][]string) {
+	headers := []string{"NAME", "ID", "TYPE", "CPU AVG", "CPU PEAK", "MEM PEAK", "NET/DAY", "CLASS", "SUGGESTION", "MONTHLY", "SAVINGS"}
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, []string{
+			r.Name, r.Id, r.InstanceType,
+			formatOptional(r.CPUAverage, "%.1f%%"),
+			formatOptional(r.CPUPeak, "%.1f%%"),
+			formatOptional(r.MemoryPeak, "%.1f%%"),
+			formatOptionalBytes(r.NetworkPerDay),
+			r.Class, emptyDash(r.Suggestion),
+			formatOptional(r.MonthlyCost, "$%.2f"),
+			formatOptional(r.MonthlySavings, "$%.2f"),
+		})
+	}
+	return headers, rows
+}
+
+func formatOptional(value *float64, format string) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf(format, *value)
+}
+
+func formatOptionalBytes(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return FormatBytes(int64(*value))
+}
+
+func emptyDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,285 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	SGSeverityHigh   = "HIGH"
+	SGSeverityMedium = "MEDIUM"
+	SGSeverityLow    = "LOW"
+
+	SGFindingWorldOpen = "world-open"
+	SGFindingStaleRule = "stale-reference"
+	SGFindingUnused    = "unattached"
+)
+
+var (
+	// 인터넷에 열려 있으면 안 되는 포트
+	SensitivePorts = map[int32]string{
+		22:    "ssh",
+		23:    "telnet",
+		445:   "smb",
+		1433:  "mssql",
+		1521:  "oracle",
+		2375:  "docker",
+		2379:  "etcd",
+		3306:  "mysql",
+		3389:  "rdp",
+		5432:  "postgresql",
+		5601:  "kibana",
+		5900:  "vnc",
+		6379:  "redis",
+		9092:  "kafka",
+		9200:  "elasticsearch",
+		11211: "memcached",
+		27017: "mongodb",
+	}
+
+	SGSeverityRank = map[string]int{SGSeverityHigh: 0, SGSeverityMedium: 1, SGSeverityLow: 2}
+)
+
+type (
+	SGFinding struct {
+		Severity  string
+		Kind      string
+		GroupId   string
+		GroupName string
+		VpcId     string
+		Detail    string
+		// 해당 보안 그룹을 사용하는 리소스 (ec2/rds/elasticache)
+		Resources []string
+	}
+)
+
+// 리전의 보안 그룹을 점검 (전체 공개 민감 포트, 미사용 그룹, 삭제된 그룹 참조)
+func AuditSecurityGroups(ctx context.Context, cfg aws.Config) ([]SGFinding, error) {
+	client := ec2.NewFromConfig(cfg)
+
+	var groups []types.SecurityGroup
+	groupPaginator := ec2.NewDescribeSecurityGroupsPaginator(client, &ec2.DescribeSecurityGroupsInput{})
+	for groupPaginator.HasMorePages() {
+		output, err := groupPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, output.SecurityGroups...)
+	}
+
+	attached := make(map[string]int)
+	eniPaginator := ec2.NewDescribeNetworkInterfacesPaginator(client, &ec2.DescribeNetworkInterfacesInput{})
+	for eniPaginator.HasMorePages() {
+		output, err := eniPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, eni := range output.NetworkInterfaces {
+			for _, group := range eni.Groups {
+				attached[aws.ToString(group.GroupId)]++
+			}
+		}
+	}
+
+	resources, err := securityGroupResources(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]struct{}, len(groups))
+	vpcs := make(map[string]struct{})
+	for _, group := range groups {
+		existing[aws.ToString(group.GroupId)] = struct{}{}
+		if group.VpcId != nil {
+			vpcs[aws.ToString(group.VpcId)] = struct{}{}
+		}
+	}
+
+	var findings []SGFinding
+	for _, group := range groups {
+		newFinding := func(severity, kind, detail string) SGFinding {
+			return SGFinding{
+				Severity:  severity,
+				Kind:      kind,
+				GroupId:   aws.ToString(group.GroupId),
+				GroupName: aws.ToString(group.GroupName),
+				VpcId:     aws.ToString(group.VpcId),
+				Detail:    detail,
+				Resources: resources[aws.ToString(group.GroupId)],
+			}
+		}
+
+		for _, permission := range group.IpPermissions {
+			for _, peer := range worldOpenPeers(permission) {
+				if ports := exposedSensitivePorts(permission); len(ports) > 0 {
+					findings = append(findings, newFinding(SGSeverityHigh, SGFindingWorldOpen, fmt.Sprintf("%s open to %s", strings.Join(ports, ", "), peer)))
+				}
+			}
+			for _, pair := range permission.UserIdGroupPairs {
+				// 피어링, 다른 계정 참조는 DescribeStaleSecurityGroups 로 확인
+				if pair.VpcPeeringConnectionId != nil {
+					continue
+				}
+				if _, ok := existing[aws.ToString(pair.GroupId)]; !ok && pair.GroupId != nil && (pair.UserId == nil || aws.ToString(pair.UserId) == aws.ToString(group.OwnerId)) {
+					findings = append(findings, newFinding(SGSeverityMedium, SGFindingStaleRule, fmt.Sprintf("inbound rule references missing group %s", aws.ToString(pair.GroupId))))
+				}
+			}
+		}
+
+		if attached[aws.ToString(group.GroupId)] == 0 && aws.ToString(group.GroupName) != "default" {
+			findings = append(findings, newFinding(SGSeverityLow, SGFindingUnused, "not attached to any network interface"))
+		}
+	}
+
+	stale, err := staleSecurityGroupFindings(ctx, client, vpcs, resources)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, stale...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return SGSeverityRank[findings[i].Severity] < SGSeverityRank[findings[j].Severity]
+		}
+		return findings[i].GroupId < findings[j].GroupId
+	})
+	return findings, nil
+}
+
+func worldOpenPeers(permission types.IpPermission) []string {
+	var peers []string
+	for _, r := range permission.IpRanges {
+		if aws.ToString(r.CidrIp) == "0.0.0.0/0" {
+			peers = append(peers, "0.0.0.0/0")
+		}
+	}
+	for _, r := range permission.Ipv6Ranges {
+		if aws.ToString(r.CidrIpv6) == "::/0" {
+			peers = append(peers, "::/0")
+		}
+	}
+	return peers
+}
+
+// 규칙의 포트 범위에 포함된 민감 포트 ("22/ssh" 형식, 정렬)
+func exposedSensitivePorts(permission types.IpPermission) []string {
+	protocol := aws.ToString(permission.IpProtocol)
+	if protocol != "-1" && protocol != "tcp" && protocol != "6" && protocol != "udp" && protocol != "17" {
+		return nil
+	}
+
+	from, to := int32(0), int32(65535)
+	if protocol != "-1" && permission.FromPort != nil && aws.ToInt32(permission.FromPort) != -1 {
+		from, to = aws.ToInt32(permission.FromPort), aws.ToInt32(permission.ToPort)
+	}
+
+	var ports []int32
+	for port := range SensitivePorts {
+		if port >= from && port <= to {
+			ports = append(ports, port)
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	labels := make([]string, 0, len(ports))
+	for _, port := range ports {
+		labels = append(labels, fmt.Sprintf("%d/%s", port, SensitivePorts[port]))
+	}
+	return labels
+}
+
+// 피어링된 VPC 에서 삭제된 그룹을 참조하는 규칙
+func staleSecurityGroupFindings(ctx context.Context, client *ec2.Client, vpcs map[string]struct{}, resources map[string][]string) ([]SGFinding, error) {
+	vpcIds := make([]string, 0, len(vpcs))
+	for vpcId := range vpcs {
+		vpcIds = append(vpcIds, vpcId)
+	}
+	sort.Strings(vpcIds)
+
+	var findings []SGFinding
+	for _, vpcId := range vpcIds {
+		paginator := ec2.NewDescribeStaleSecurityGroupsPaginator(client, &ec2.DescribeStaleSecurityGroupsInput{VpcId: aws.String(vpcId)})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, group := range output.StaleSecurityGroupSet {
+				var references []string
+				for _, permission := range append(group.StaleIpPermissions, group.StaleIpPermissionsEgress...) {
+					for _, pair := range permission.UserIdGroupPairs {
+						references = append(references, aws.ToString(pair.GroupId))
+					}
+				}
+				findings = append(findings, SGFinding{
+					Severity:  SGSeverityMedium,
+					Kind:      SGFindingStaleRule,
+					GroupId:   aws.ToString(group.GroupId),
+					GroupName: aws.ToString(group.GroupName),
+					VpcId:     aws.ToString(group.VpcId),
+					Detail:    fmt.Sprintf("rules reference deleted or unreachable groups %s", strings.Join(references, ", ")),
+					Resources: resources[aws.ToString(group.GroupId)],
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// 보안 그룹 ID → 사용 중인 리소스 ("ec2 web-1 (i-...)" 형식)
+func securityGroupResources(ctx context.Context, cfg aws.Config) (map[string][]string, error) {
+	resources := make(map[string][]string)
+
+	instances, err := FindInstanceWithFilter(ctx, cfg, InstanceFilter{States: InstanceStates[:len(InstanceStates)-1]})
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		for _, group := range instance.SecurityGroups {
+			resources[group] = append(resources[group], fmt.Sprintf("ec2 %s (%s)", instance.Name, instance.Id))
+		}
+	}
+
+	rdsInstances, err := FindRdsInstance(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, db := range rdsInstances {
+		for _, group := range db.SecurityGroups {
+			resources[group] = append(resources[group], fmt.Sprintf("rds %s", db.Id))
+		}
+	}
+
+	clusters, err := FindElastiCacheCluster(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		for _, group := range cluster.SecurityGroups {
+			resources[group] = append(resources[group], fmt.Sprintf("elasticache %s", cluster.Id))
+		}
+	}
+
+	for group := range resources {
+		sort.Strings(resources[group])
+	}
+	return resources, nil
+}
+
+func PrintSGFindings(findings []SGFinding) {
+	rows := make([][]string, 0, len(findings))
+	for _, finding := range findings {
+		exposed := strings.Join(finding.Resources, ", ")
+		if exposed == "" {
+			exposed = "-"
+		}
+		rows = append(rows, []string{finding.Severity, finding.Kind, fmt.Sprintf("%s (%s)", finding.GroupId, finding.GroupName), finding.VpcId, finding.Detail, exposed})
+	}
+	LogTable([]string{"SEVERITY", "FINDING", "GROUP", "VPC", "DETAIL", "RESOURCES"}, rows)
+}
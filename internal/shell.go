@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/term"
+)
+
+type (
+	ShellOptions struct {
+		User         string
+		ForwardAgent bool
+		Env          map[string]string
+	}
+)
+
+const (
+	defaultTerm = "xterm-256color"
+)
+
+// --env 값 파싱 (KEY=VALUE, 값이 없으면 로컬 환경 변수 전달)
+func ParseShellEnv(values []string) (map[string]string, error) {
+	env := make(map[string]string, len(values))
+	for _, value := range values {
+		key, envValue, ok := strings.Cut(value, "=")
+		if key = strings.TrimSpace(key); key == "" {
+			return nil, fmt.Errorf("invalid env %q (expected KEY=VALUE or KEY)", value)
+		}
+		if !ok {
+			envValue = os.Getenv(key)
+		}
+		env[key] = envValue
+	}
+	return env, nil
+}
+
+// bastion 을 경유해 타겟 서버에 대화형 셸 연결 (PTY, raw 모드, 창 크기 변경 전달)
+func StartInteractiveShell(bastion *ssh.Client, target *Target, options ShellOptions) error {
+	targetClient, err := connectTarget(bastion, target, options.User)
+	if err != nil {
+		return err
+	}
+	defer targetClient.Close()
+
+	session, err := targetClient.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	if options.ForwardAgent {
+		if err := forwardAgent(targetClient, session); err != nil {
+			LogWarning("agent forwarding 을 사용할 수 없습니다: %v", err)
+		}
+	}
+
+	// 서버 sshd 의 AcceptEnv 에 없는 변수는 거부될 수 있음
+	for key, value := range options.Env {
+		if err := session.Setenv(key, value); err != nil {
+			LogWarning("환경 변수 전달 실패 (sshd AcceptEnv 확인): %s", key)
+		}
+	}
+
+	session.Stdin = os.Stdin
+	session.Stdout = os.Stdout
+	session.Stderr = os.Stderr
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(fd, state)
+
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = 80, 24
+		}
+		termType := os.Getenv("TERM")
+		if termType == "" {
+			termType = defaultTerm
+		}
+		modes := ssh.TerminalModes{
+			ssh.ECHO:          1,
+			ssh.TTY_OP_ISPEED: 14400,
+			ssh.TTY_OP_OSPEED: 14400,
+		}
+		if err := session.RequestPty(termType, height, width, modes); err != nil {
+			return err
+		}
+
+		stop := watchWindowSize(fd, func(width, height int) {
+			session.WindowChange(height, width)
+		})
+		defer stop()
+	}
+
+	if err := session.Shell(); err != nil {
+		return err
+	}
+	return session.Wait()
+}
+
+// 로컬 ssh-agent(SSH_AUTH_SOCK)를 타겟 서버로 전달
+func forwardAgent(client *ssh.Client, session *ssh.Session) error {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return fmt.Errorf("SSH_AUTH_SOCK is not set")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return err
+	}
+	conn.Close()
+
+	if err := agent.ForwardToRemote(client, socket); err != nil {
+		return err
+	}
+	return agent.RequestAgentForwarding(session)
+}
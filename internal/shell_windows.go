@@ -0,0 +1,40 @@
+//go:build windows
+
+package internal
+
+import (
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	windowSizePollInterval = 500 * time.Millisecond
+)
+
+// Windows 에는 SIGWINCH 가 없어 주기적으로 터미널 크기 확인
+func watchWindowSize(fd int, onResize func(width, height int)) func() {
+	done := make(chan struct{})
+	width, height, _ := term.GetSize(fd)
+
+	go func() {
+		ticker := time.NewTicker(windowSizePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w, h, err := term.GetSize(fd)
+				if err == nil && (w != width || h != height) {
+					width, height = w, h
+					onResize(width, height)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
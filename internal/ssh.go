@@ -2,7 +2,10 @@ package internal
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"os"
 	"strconv"
@@ -23,6 +26,8 @@ const (
 	maxPoolSize     = 50
 	connTimeout     = 30 * time.Second
 	cleanupInterval = 5 * time.Minute
+	// 장시간 연결 keepalive 간격
+	KeepAliveInterval = 30 * time.Second
 )
 
 // SSH 연결 풀 구조체
@@ -51,8 +56,8 @@ func getConnectionKey(host, keyName string) string {
 }
 
 // SSH 연결 풀에서 연결 가져오기
-func (p *SSHConnectionPool) getConnection(host, keyName string) (*ssh.Client, error) {
-	key := getConnectionKey(host, keyName)
+func (p *SSHConnectionPool) getConnection(bastion *Target) (*ssh.Client, error) {
+	key := getConnectionKey(bastion.PublicIp, bastion.KeyName)
 
 	p.mutex.RLock()
 	if conn, exists := p.connections[key]; exists {
@@ -69,27 +74,17 @@ func (p *SSHConnectionPool) getConnection(host, keyName string) (*ssh.Client, er
 	p.mutex.RUnlock()
 
 	// 새로운 연결 생성
-	return p.createConnection(host, keyName)
+	return p.createConnection(bastion)
 }
 
 // 새로운 SSH 연결 생성
-func (p *SSHConnectionPool) createConnection(host, keyName string) (*ssh.Client, error) {
-	config, err := getSSHClientConfigCached(keyName)
+func (p *SSHConnectionPool) createConnection(bastion *Target) (*ssh.Client, error) {
+	client, err := DialBastion(bastion)
 	if err != nil {
 		return nil, err
 	}
 
-	var client *ssh.Client
-	err = retry(maxRetries, retryDelay, func() error {
-		var err error
-		client, err = ssh.Dial("tcp", host+":22", config)
-		return err
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	key := getConnectionKey(host, keyName)
+	key := getConnectionKey(bastion.PublicIp, bastion.KeyName)
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
@@ -160,8 +155,8 @@ func (p *SSHConnectionPool) cleanup() {
 	}
 }
 
-// SSH 클라이언트 설정 캐시 (키: keyPath, 값: *ssh.ClientConfig)
-var sshClientConfigCache sync.Map
+// pem 키 캐시 (키: keyPath, 값: ssh.Signer)
+var sshKeySignerCache sync.Map
 
 // 재시도 헬퍼 함수
 func retry(attempts int, delay time.Duration, operation func() error) error {
@@ -175,24 +170,13 @@ func retry(attempts int, delay time.Duration, operation func() error) error {
 	return err
 }
 
-// 키 파일 경로를 기반으로 캐싱된 SSH 클라이언트 설정을 반환합니다.
-func getSSHClientConfigCached(keyName string) (*ssh.ClientConfig, error) {
+// 키 파일 경로를 기반으로 캐싱된 서명 키를 반환합니다.
+func getKeySignerCached(keyName string) (ssh.Signer, error) {
 	home := FindHomeFolder()
 	keyPath := fmt.Sprintf("%s/.ssh/%s.pem", home, keyName)
-	if cfg, ok := sshClientConfigCache.Load(keyPath); ok {
-		return cfg.(*ssh.ClientConfig), nil
+	if signer, ok := sshKeySignerCache.Load(keyPath); ok {
+		return signer.(ssh.Signer), nil
 	}
-	cfg, err := newSSHClientConfig(keyName)
-	if err != nil {
-		return nil, err
-	}
-	sshClientConfigCache.Store(keyPath, cfg)
-	return cfg, nil
-}
-
-func newSSHClientConfig(keyName string) (*ssh.ClientConfig, error) {
-	home := FindHomeFolder()
-	keyPath := fmt.Sprintf("%s/.ssh/%s.pem", home, keyName)
 	key, err := os.ReadFile(keyPath)
 	if err != nil {
 		return nil, err
@@ -201,18 +185,91 @@ func newSSHClientConfig(keyName string) (*ssh.ClientConfig, error) {
 	if err != nil {
 		return nil, err
 	}
-	config := &ssh.ClientConfig{
-		User:            defaultUser,
-		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+	sshKeySignerCache.Store(keyPath, signer)
+	return signer, nil
+}
+
+func newSSHClientConfig(user string, signers ...ssh.Signer) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signers...)},
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 		Timeout:         sshDialTimeout,
 	}
-	return config, nil
+}
+
+// 접속 인증 순서: mcl cert 인증서(유효한 경우) → ~/.ssh/<KeyName>.pem → EC2 Instance Connect 임시 키
+func sshClientConfigFor(target *Target, user string) (*ssh.ClientConfig, error) {
+	if user == "" {
+		user = defaultUser
+	}
+
+	var signers []ssh.Signer
+	if certSigner := userCertificateSigner(); certSigner != nil {
+		signers = append(signers, certSigner)
+	}
+
+	mode, cfg := instanceConnectSettings()
+	if mode != InstanceConnectAlways {
+		signer, err := getKeySignerCached(target.KeyName)
+		if err == nil {
+			return newSSHClientConfig(user, append(signers, signer)...), nil
+		}
+		if mode == InstanceConnectNever || !errors.Is(err, fs.ErrNotExist) || cfg == nil {
+			if len(signers) > 0 {
+				return newSSHClientConfig(user, signers...), nil
+			}
+			return nil, err
+		}
+	}
+	signer, err := instanceConnectSigner(cfg, target, user)
+	if err != nil {
+		// 키 전송 권한이 없어도 인증서가 있으면 인증서만으로 접속 시도
+		if len(signers) > 0 {
+			LogWarning("ec2 instance connect 실패, 인증서로만 접속합니다: %v", err)
+			return newSSHClientConfig(user, signers...), nil
+		}
+		return nil, err
+	}
+	return newSSHClientConfig(user, append(signers, signer)...), nil
 }
 
 // 개선된 Bastion 연결 함수 (연결 풀 사용)
-func ConnectionBastion(bastionHost, keyName string) (*ssh.Client, error) {
-	return sshConnectionPool.getConnection(bastionHost, keyName)
+func ConnectionBastion(bastion *Target) (*ssh.Client, error) {
+	return sshConnectionPool.getConnection(bastion)
+}
+
+// 세션, 터널처럼 오래 유지되는 연결용 (풀에서 만료 정리되지 않도록 별도 연결)
+func DialBastion(bastion *Target) (*ssh.Client, error) {
+	config, err := sshClientConfigFor(bastion, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var client *ssh.Client
+	err = retry(maxRetries, retryDelay, func() error {
+		var err error
+		client, err = ssh.Dial("tcp", bastion.PublicIp+":22", config)
+		return err
+	})
+	return client, err
+}
+
+// 유휴 연결이 NAT, 방화벽에서 끊기지 않도록 주기적으로 keepalive 전송
+func KeepAlive(ctx context.Context, client *ssh.Client, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, _, err := client.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 // 개선된 볼륨 사용량 조회 함수 (연결 풀 사용)
@@ -251,13 +308,22 @@ func GetVolumeUsage(bastion *ssh.Client, target *Target) (int, error) {
 
 // 타겟 서버 연결 생성 (연결 풀 사용)
 func getTargetConnection(bastion *ssh.Client, target *Target) (*ssh.Client, error) {
-	config, err := getSSHClientConfigCached(target.KeyName)
+	return connectTarget(bastion, target, "")
+}
+
+// 사용자를 지정해 타겟 서버 연결 (비어 있으면 기본 사용자)
+func connectTarget(bastion *ssh.Client, target *Target, user string) (*ssh.Client, error) {
+	config, err := sshClientConfigFor(target, user)
 	if err != nil {
 		return nil, err
 	}
+	return dialTarget(bastion, target, config)
+}
 
+// bastion 을 경유해 타겟 서버의 사설 IP 로 SSH 연결
+func dialTarget(bastion *ssh.Client, target *Target, config *ssh.ClientConfig) (*ssh.Client, error) {
 	var conn net.Conn
-	err = retry(maxRetries, retryDelay, func() error {
+	err := retry(maxRetries, retryDelay, func() error {
 		var err error
 		conn, err = bastion.Dial("tcp", target.PrivateIp+":22")
 		return err
@@ -0,0 +1,300 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+type (
+	SSHHost struct {
+		Alias        string
+		HostName     string
+		User         string
+		IdentityFile string
+		ProxyJump    string
+		Comment      string
+	}
+)
+
+const (
+	sshConfigInclude     = "Include config.d/mcl"
+	sshConfigHeader      = "# Managed by `mcl ssh-config`. Sections are rewritten on every run; do not edit them by hand."
+	sshConfigBeginMarker = "# BEGIN mcl "
+	sshConfigEndMarker   = "# END mcl "
+	describeImagesBatch  = 100
+)
+
+var (
+	aliasInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+	// AMI 이름으로 기본 로그인 사용자 추정 (https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/managing-users.html)
+	amiUsers = []struct {
+		keyword string
+		user    string
+	}{
+		{"ubuntu", "ubuntu"},
+		{"debian", "admin"},
+		{"centos", "centos"},
+		{"rocky", "rocky"},
+		{"almalinux", "ec2-user"},
+		{"fedora", "fedora"},
+		{"bitnami", "bitnami"},
+		{"amzn", "ec2-user"},
+		{"al2023", "ec2-user"},
+		{"rhel", "ec2-user"},
+		{"suse", "ec2-user"},
+	}
+)
+
+// ~/.ssh/config 에서 Include 하는 mcl 관리 파일 경로
+func SSHConfigPath() string {
+	return filepath.Join(FindHomeFolder(), ".ssh", "config.d", "mcl")
+}
+
+// 인스턴스 AMI 이름으로 로그인 사용자 추정 (키: ImageId)
+func DetectSSHUsers(ctx context.Context, cfg aws.Config, targets []*Target) (map[string]string, error) {
+	imageSet := make(map[string]struct{})
+	for _, target := range targets {
+		if target.ImageId != "" {
+			imageSet[target.ImageId] = struct{}{}
+		}
+	}
+	imageIds := make([]string, 0, len(imageSet))
+	for id := range imageSet {
+		imageIds = append(imageIds, id)
+	}
+
+	client := ec2.NewFromConfig(cfg)
+	users := make(map[string]string, len(imageIds))
+	for start := 0; start < len(imageIds); start += describeImagesBatch {
+		end := start + describeImagesBatch
+		if end > len(imageIds) {
+			end = len(imageIds)
+		}
+
+		output, err := client.DescribeImages(ctx, &ec2.DescribeImagesInput{ImageIds: imageIds[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range output.Images {
+			name := strings.ToLower(aws.ToString(image.Name) + " " + aws.ToString(image.Description))
+			for _, candidate := range amiUsers {
+				if strings.Contains(name, candidate.keyword) {
+					users[aws.ToString(image.ImageId)] = candidate.user
+					break
+				}
+			}
+		}
+	}
+	return users, nil
+}
+
+// Name 태그 기반 별칭 (소문자, 허용 문자 외 '-'), 이름이 없으면 인스턴스 ID
+func SSHHostAlias(prefix string, target *Target) string {
+	alias := strings.Trim(aliasInvalidChars.ReplaceAllString(strings.ToLower(target.Name), "-"), "-")
+	if alias == "" {
+		alias = target.Id
+	}
+	return prefix + alias
+}
+
+// bastion 을 ProxyJump 로 하는 Host 블록 목록 (이름이 겹치면 인스턴스 ID 를 붙여 구분)
+func BuildSSHHosts(bastion *Target, targets []*Target, users map[string]string, prefix, userOverride string) []SSHHost {
+	userFor := func(target *Target) string {
+		if userOverride != "" {
+			return userOverride
+		}
+		if user, ok := users[target.ImageId]; ok {
+			return user
+		}
+		return defaultUser
+	}
+	identityFile := func(target *Target) string {
+		if target.KeyName == "" {
+			return ""
+		}
+		return fmt.Sprintf("~/.ssh/%s.pem", target.KeyName)
+	}
+
+	bastionAlias := SSHHostAlias(prefix, bastion)
+	hosts := []SSHHost{{
+		Alias:        bastionAlias,
+		HostName:     bastion.PublicIp,
+		User:         userFor(bastion),
+		IdentityFile: identityFile(bastion),
+		Comment:      fmt.Sprintf("%s (bastion)", bastion.Id),
+	}}
+
+	sorted := make([]*Target, 0, len(targets))
+	for _, target := range targets {
+		if target.Id != bastion.Id && target.PrivateIp != "" {
+			sorted = append(sorted, target)
+		}
+	}
+	SortTargets(sorted)
+	aliases := assignSSHAliases(prefix, bastionAlias, sorted)
+	for _, target := range sorted {
+		hosts = append(hosts, SSHHost{
+			Alias:        aliases[target.Id],
+			HostName:     target.PrivateIp,
+			User:         userFor(target),
+			IdentityFile: identityFile(target),
+			ProxyJump:    bastionAlias,
+			Comment:      target.Id,
+		})
+	}
+	return hosts
+}
+
+// 인스턴스 ID 별 별칭. 입력 순서와 무관하게 같은 인스턴스 집합이면 같은 결과
+// 겹치는 이름은 모두 -<인스턴스 ID> 를 붙이고, 그래도 겹치면 인스턴스 ID 만 사용
+func assignSSHAliases(prefix, bastionAlias string, targets []*Target) map[string]string {
+	byId := append([]*Target(nil), targets...)
+	sort.Slice(byId, func(i, j int) bool { return byId[i].Id < byId[j].Id })
+
+	count := make(map[string]int)
+	for _, target := range byId {
+		count[SSHHostAlias(prefix, target)]++
+	}
+
+	aliases := make(map[string]string, len(byId))
+	used := map[string]struct{}{bastionAlias: {}}
+	var collided []*Target
+	for _, target := range byId {
+		alias := SSHHostAlias(prefix, target)
+		if count[alias] > 1 || alias == bastionAlias {
+			collided = append(collided, target)
+			continue
+		}
+		aliases[target.Id] = alias
+		used[alias] = struct{}{}
+	}
+	for _, target := range collided {
+		alias := fmt.Sprintf("%s-%s", SSHHostAlias(prefix, target), target.Id)
+		if _, ok := used[alias]; ok {
+			alias = prefix + target.Id
+		}
+		aliases[target.Id] = alias
+		used[alias] = struct{}{}
+	}
+	return aliases
+}
+
+func RenderSSHHosts(hosts []SSHHost) string {
+	var b strings.Builder
+	for _, host := range hosts {
+		if host.Comment != "" {
+			fmt.Fprintf(&b, "# %s\n", host.Comment)
+		}
+		fmt.Fprintf(&b, "Host %s\n", host.Alias)
+		fmt.Fprintf(&b, "    HostName %s\n", host.HostName)
+		fmt.Fprintf(&b, "    User %s\n", host.User)
+		if host.IdentityFile != "" {
+			fmt.Fprintf(&b, "    IdentityFile %s\n", host.IdentityFile)
+			fmt.Fprintf(&b, "    IdentitiesOnly yes\n")
+		}
+		if host.ProxyJump != "" {
+			fmt.Fprintf(&b, "    ProxyJump %s\n", host.ProxyJump)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// 관리 파일에서 section(profile/region) 부분만 교체, 없으면 추가 (다른 섹션은 유지)
+func WriteSSHConfigSection(path, section, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	sections := parseSSHConfigSections(string(existing))
+	sections[section] = strings.TrimRight(content, "\n") + "\n"
+
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.WriteString(sshConfigHeader + "\n\n")
+	for _, name := range names {
+		b.WriteString(sshConfigBeginMarker + name + "\n")
+		b.WriteString(sections[name])
+		b.WriteString(sshConfigEndMarker + name + "\n\n")
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b.Bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func parseSSHConfigSections(content string) map[string]string {
+	sections := make(map[string]string)
+
+	var current string
+	var body strings.Builder
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(trimmed, sshConfigBeginMarker):
+			current = strings.TrimPrefix(trimmed, sshConfigBeginMarker)
+			body.Reset()
+		case current != "" && trimmed == sshConfigEndMarker+current:
+			sections[current] = body.String()
+			current = ""
+		case current != "":
+			body.WriteString(line)
+		}
+	}
+	return sections
+}
+
+// ~/.ssh/config 에 Include 가 있는지 확인
+func HasSSHConfigInclude() (bool, error) {
+	content, err := os.ReadFile(filepath.Join(FindHomeFolder(), ".ssh", "config"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == sshConfigInclude {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Include 는 첫 Host 블록보다 앞에 있어야 적용되므로 파일 맨 앞에 추가
+func AddSSHConfigInclude() error {
+	path := filepath.Join(FindHomeFolder(), ".ssh", "config")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	mode := os.FileMode(0600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	content := append([]byte(sshConfigInclude+"\n\n"), existing...)
+	return os.WriteFile(path, content, mode)
+}
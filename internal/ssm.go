@@ -5,6 +5,18 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+const (
+	// SendCommand TimeoutSeconds 허용 범위
+	ssmMinTimeoutSeconds int64 = 30
+	ssmMaxTimeoutSeconds int64 = 2592000
 )
 
 // SSM 클라이언트 설치 여부 확인
@@ -42,3 +54,51 @@ func StartSSMSession(ctx context.Context, instanceId, region string) error {
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+// SSM Run Command 로 셸 명령 실행 후 결과 대기 (Windows 는 PowerShell)
+func RunSSMCommand(ctx context.Context, cfg aws.Config, target *Target, command string, timeout time.Duration) (string, error) {
+	client := ssm.NewFromConfig(cfg)
+
+	document := "AWS-RunShellScript"
+	if strings.Contains(strings.ToLower(target.Platform), "windows") {
+		document = "AWS-RunPowerShellScript"
+	}
+
+	sent, err := client.SendCommand(ctx, &ssm.SendCommandInput{
+		DocumentName:   aws.String(document),
+		InstanceIds:    []string{target.Id},
+		Parameters:     map[string][]string{"commands": {command}},
+		TimeoutSeconds: aws.Int32(ssmCommandTimeoutSeconds(timeout)),
+		Comment:        aws.String("mcl ec2 run"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	input := &ssm.GetCommandInvocationInput{
+		CommandId:  sent.Command.CommandId,
+		InstanceId: aws.String(target.Id),
+	}
+	output, waitErr := ssm.NewCommandExecutedWaiter(client).WaitForOutput(ctx, input, timeout)
+	if output == nil {
+		// 실패 상태(Failed, TimedOut 등)도 출력은 조회
+		output, err = client.GetCommandInvocation(ctx, input)
+		if err != nil {
+			return "", waitErr
+		}
+	}
+
+	result := aws.ToString(output.StandardOutputContent)
+	if stderr := aws.ToString(output.StandardErrorContent); stderr != "" {
+		result = strings.TrimRight(result, "\n") + "\n" + stderr
+	}
+	if output.Status != types.CommandInvocationStatusSuccess {
+		return result, fmt.Errorf("command %s (exit code %d): %s", output.Status, output.ResponseCode, strings.TrimSpace(result))
+	}
+	return result, nil
+}
+
+// SendCommand 의 TimeoutSeconds 는 30 ~ 2592000 (짧은 --wait-timeout 은 로컬 대기에만 적용)
+func ssmCommandTimeoutSeconds(timeout time.Duration) int32 {
+	return int32(max(ssmMinTimeoutSeconds, min(int64(timeout.Seconds()), ssmMaxTimeoutSeconds)))
+}
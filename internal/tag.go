@@ -0,0 +1,507 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	elasticachetypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	ResourceEC2         = "ec2"
+	ResourceVolume      = "volume"
+	ResourceRDS         = "rds"
+	ResourceElastiCache = "elasticache"
+
+	// CreateTags, DeleteTags 한 번에 보낼 리소스 수
+	ec2TagBatchSize = 500
+	// aws: 로 시작하는 태그는 AWS 예약 태그라 수정할 수 없음
+	reservedTagPrefix = "aws:"
+)
+
+var (
+	TagResourceTypes = []string{ResourceEC2, ResourceVolume, ResourceRDS, ResourceElastiCache}
+)
+
+type (
+	TaggedResource struct {
+		Type string
+		Id   string
+		// RDS, ElastiCache 태그 API 는 ARN 사용
+		Arn  string
+		Name string
+		Tags map[string]string
+		// EBS 볼륨이 연결된 인스턴스 ID
+		AttachedTo string
+	}
+
+	// 리소스 하나에 적용할 태그 변경 (Set: 추가/변경, Remove: 삭제)
+	TagChange struct {
+		Resource *TaggedResource
+		Set      map[string]string
+		Remove   []string
+	}
+
+	TagChangeResult struct {
+		Change TagChange
+		Err    error
+	}
+)
+
+func (r *TaggedResource) Label() string {
+	if r.Name == "" || r.Name == r.Id {
+		return fmt.Sprintf("%s %s", r.Type, r.Id)
+	}
+	return fmt.Sprintf("%s %s (%s)", r.Type, r.Id, r.Name)
+}
+
+func (c TagChange) Empty() bool {
+	return len(c.Set) == 0 && len(c.Remove) == 0
+}
+
+func ValidateResourceType(resourceType string) error {
+	for _, t := range TagResourceTypes {
+		if t == resourceType {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid resource type %q (one of %s)", resourceType, strings.Join(TagResourceTypes, ", "))
+}
+
+// KEY=VALUE 목록 파싱 (값은 비어 있을 수 있음)
+func ParseTags(values []string) (map[string]string, error) {
+	tags := make(map[string]string, len(values))
+	for _, value := range values {
+		key, tagValue, ok := strings.Cut(value, "=")
+		if key = strings.TrimSpace(key); key == "" || !ok {
+			return nil, fmt.Errorf("invalid tag %q (expected key=value)", value)
+		}
+		if strings.HasPrefix(key, reservedTagPrefix) {
+			return nil, fmt.Errorf("tag %q uses the reserved aws: prefix", key)
+		}
+		tags[key] = tagValue
+	}
+	return tags, nil
+}
+
+// 리소스 종류별 조회 후 태그 조건(같은 키는 OR, 다른 키는 AND, 값 glob)과 ID 목록으로 거름
+func FindTaggedResources(ctx context.Context, cfg aws.Config, resourceType string, filters []TagFilter, ids []string) ([]*TaggedResource, error) {
+	var resources []*TaggedResource
+	var err error
+	switch resourceType {
+	case ResourceEC2:
+		resources, err = findEC2TaggedResources(ctx, cfg, filters)
+	case ResourceVolume:
+		resources, err = findVolumeTaggedResources(ctx, cfg, tagFilters(filters), nil)
+	case ResourceRDS:
+		resources, err = findRdsTaggedResources(ctx, cfg)
+	case ResourceElastiCache:
+		resources, err = findElastiCacheTaggedResources(ctx, cfg)
+	default:
+		err = ValidateResourceType(resourceType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	idSet := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			idSet[id] = struct{}{}
+		}
+	}
+
+	matched := make([]*TaggedResource, 0, len(resources))
+	for _, resource := range resources {
+		if _, ok := idSet[resource.Id]; len(idSet) > 0 && !ok {
+			continue
+		}
+		if matchTagFilters(filters, resource.Tags) {
+			matched = append(matched, resource)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Name != matched[j].Name {
+			return matched[i].Name < matched[j].Name
+		}
+		return matched[i].Id < matched[j].Id
+	})
+	return matched, nil
+}
+
+func matchTagFilters(filters []TagFilter, tags map[string]string) bool {
+	byKey := make(map[string][]string)
+	for _, filter := range filters {
+		byKey[filter.Key] = append(byKey[filter.Key], filter.Value)
+	}
+	for key, values := range byKey {
+		value, ok := tags[key]
+		if !ok {
+			return false
+		}
+		matched := false
+		for _, pattern := range values {
+			if ok, _ := path.Match(pattern, value); pattern == "" || ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func findEC2TaggedResources(ctx context.Context, cfg aws.Config, filters []TagFilter) ([]*TaggedResource, error) {
+	table, err := FindInstanceWithFilter(ctx, cfg, InstanceFilter{States: InstanceStates[:len(InstanceStates)-1], Tags: filters})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*TaggedResource, 0, len(table))
+	for _, target := range table {
+		resources = append(resources, &TaggedResource{Type: ResourceEC2, Id: target.Id, Name: target.Name, Tags: target.Tags})
+	}
+	return resources, nil
+}
+
+func findVolumeTaggedResources(ctx context.Context, cfg aws.Config, filters []ec2types.Filter, instanceIds []string) ([]*TaggedResource, error) {
+	if len(instanceIds) > 0 {
+		filters = append(filters, ec2types.Filter{Name: aws.String("attachment.instance-id"), Values: instanceIds})
+	}
+	input := &ec2.DescribeVolumesInput{}
+	if len(filters) > 0 {
+		input.Filters = filters
+	}
+
+	var resources []*TaggedResource
+	paginator := ec2.NewDescribeVolumesPaginator(ec2.NewFromConfig(cfg), input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, volume := range output.Volumes {
+			tags := make(map[string]string, len(volume.Tags))
+			for _, tag := range volume.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			resource := &TaggedResource{Type: ResourceVolume, Id: aws.ToString(volume.VolumeId), Name: tags["Name"], Tags: tags}
+			for _, attachment := range volume.Attachments {
+				resource.AttachedTo = aws.ToString(attachment.InstanceId)
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+func findRdsTaggedResources(ctx context.Context, cfg aws.Config) ([]*TaggedResource, error) {
+	var resources []*TaggedResource
+	paginator := rds.NewDescribeDBInstancesPaginator(rds.NewFromConfig(cfg), &rds.DescribeDBInstancesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, dbInstance := range output.DBInstances {
+			target := newRdsTarget(dbInstance)
+			tags := make(map[string]string, len(dbInstance.TagList))
+			for _, tag := range dbInstance.TagList {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			resources = append(resources, &TaggedResource{Type: ResourceRDS, Id: target.Id, Arn: aws.ToString(dbInstance.DBInstanceArn), Name: target.Name, Tags: tags})
+		}
+	}
+	return resources, nil
+}
+
+func findElastiCacheTaggedResources(ctx context.Context, cfg aws.Config) ([]*TaggedResource, error) {
+	client := elasticache.NewFromConfig(cfg)
+
+	var resources []*TaggedResource
+	paginator := elasticache.NewDescribeCacheClustersPaginator(client, &elasticache.DescribeCacheClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range output.CacheClusters {
+			// DescribeCacheClusters 는 태그를 포함하지 않음
+			tagOutput, err := client.ListTagsForResource(ctx, &elasticache.ListTagsForResourceInput{ResourceName: cluster.ARN})
+			if err != nil {
+				return nil, err
+			}
+			tags := make(map[string]string, len(tagOutput.TagList))
+			for _, tag := range tagOutput.TagList {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			resources = append(resources, &TaggedResource{Type: ResourceElastiCache, Id: aws.ToString(cluster.CacheClusterId), Arn: aws.ToString(cluster.ARN), Name: aws.ToString(cluster.CacheClusterId), Tags: tags})
+		}
+	}
+	return resources, nil
+}
+
+// 인스턴스에 연결된 EBS 볼륨
+func FindAttachedVolumes(ctx context.Context, cfg aws.Config, instanceIds []string) ([]*TaggedResource, error) {
+	if len(instanceIds) == 0 {
+		return nil, nil
+	}
+	return findVolumeTaggedResources(ctx, cfg, nil, instanceIds)
+}
+
+func AskTaggedResources(resources []*TaggedResource, message string) ([]*TaggedResource, error) {
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no resource found")
+	}
+
+	displayMap := make(map[string]*TaggedResource, len(resources))
+	options := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		option := resource.Label()
+		options = append(options, option)
+		displayMap[option] = resource
+	}
+
+	var selected []string
+	prompt := &survey.MultiSelect{Message: message, Options: options}
+	if err := survey.AskOne(prompt, &selected, survey.WithPageSize(20), survey.WithValidator(survey.Required)); err != nil {
+		return nil, err
+	}
+
+	picked := make([]*TaggedResource, 0, len(selected))
+	for _, option := range selected {
+		picked = append(picked, displayMap[option])
+	}
+	return picked, nil
+}
+
+// 현재 태그와 비교해 실제로 바뀌는 항목만 남긴 변경
+func DiffTags(resource *TaggedResource, set map[string]string, remove []string) TagChange {
+	change := TagChange{Resource: resource, Set: make(map[string]string)}
+	for key, value := range set {
+		if strings.HasPrefix(key, reservedTagPrefix) {
+			continue
+		}
+		if current, ok := resource.Tags[key]; !ok || current != value {
+			change.Set[key] = value
+		}
+	}
+	for _, key := range remove {
+		if _, ok := resource.Tags[key]; ok {
+			change.Remove = append(change.Remove, key)
+		}
+	}
+	sort.Strings(change.Remove)
+	return change
+}
+
+// 원본 태그 중 keys(비어 있으면 전체, aws: 제외)만 추림
+func SelectTags(tags map[string]string, keys []string) map[string]string {
+	selected := make(map[string]string)
+	for key, value := range tags {
+		if strings.HasPrefix(key, reservedTagPrefix) {
+			continue
+		}
+		if len(keys) == 0 {
+			selected[key] = value
+			continue
+		}
+		for _, k := range keys {
+			if k == key {
+				selected[key] = value
+			}
+		}
+	}
+	return selected
+}
+
+// 변경 사항을 리소스 종류별로 모아 적용 (EC2, EBS 는 추가할 태그 집합, 삭제할 키 목록이 같은 리소스끼리 묶어 호출)
+func ApplyTagChanges(ctx context.Context, cfg aws.Config, changes []TagChange) []TagChangeResult {
+	type ec2Batch struct {
+		tags []ec2types.Tag
+		ids  []string
+	}
+	var createKeys, deleteKeys []string
+	createBatches := make(map[string]*ec2Batch)
+	deleteBatches := make(map[string]*ec2Batch)
+	addToBatch := func(batches map[string]*ec2Batch, keys *[]string, key string, tags []ec2types.Tag, id string) {
+		batch, ok := batches[key]
+		if !ok {
+			batch = &ec2Batch{tags: tags}
+			batches[key] = batch
+			*keys = append(*keys, key)
+		}
+		batch.ids = append(batch.ids, id)
+	}
+
+	errs := make(map[*TaggedResource]error)
+	var applied []TagChange
+	for _, change := range changes {
+		if change.Empty() {
+			continue
+		}
+		applied = append(applied, change)
+
+		switch change.Resource.Type {
+		case ResourceEC2, ResourceVolume:
+			if len(change.Set) > 0 {
+				key, tags := ec2TagSet(change.Set)
+				addToBatch(createBatches, &createKeys, key, tags, change.Resource.Id)
+			}
+			if len(change.Remove) > 0 {
+				tags := make([]ec2types.Tag, 0, len(change.Remove))
+				for _, key := range change.Remove {
+					tags = append(tags, ec2types.Tag{Key: aws.String(key)})
+				}
+				addToBatch(deleteBatches, &deleteKeys, strings.Join(change.Remove, "\x00"), tags, change.Resource.Id)
+			}
+		case ResourceRDS:
+			errs[change.Resource] = applyRdsTagChange(ctx, rds.NewFromConfig(cfg), change)
+		case ResourceElastiCache:
+			errs[change.Resource] = applyElastiCacheTagChange(ctx, elasticache.NewFromConfig(cfg), change)
+		}
+	}
+
+	client := ec2.NewFromConfig(cfg)
+	failedIds := make(map[string]error)
+	run := func(batches map[string]*ec2Batch, keys []string, call func(ids []string, tags []ec2types.Tag) error) {
+		for _, key := range keys {
+			batch := batches[key]
+			for start := 0; start < len(batch.ids); start += ec2TagBatchSize {
+				end := start + ec2TagBatchSize
+				if end > len(batch.ids) {
+					end = len(batch.ids)
+				}
+				if err := call(batch.ids[start:end], batch.tags); err != nil {
+					for _, id := range batch.ids[start:end] {
+						if failedIds[id] == nil {
+							failedIds[id] = err
+						}
+					}
+				}
+			}
+		}
+	}
+	run(createBatches, createKeys, func(ids []string, tags []ec2types.Tag) error {
+		_, err := client.CreateTags(ctx, &ec2.CreateTagsInput{Resources: ids, Tags: tags})
+		return err
+	})
+	run(deleteBatches, deleteKeys, func(ids []string, tags []ec2types.Tag) error {
+		_, err := client.DeleteTags(ctx, &ec2.DeleteTagsInput{Resources: ids, Tags: tags})
+		return err
+	})
+
+	results := make([]TagChangeResult, 0, len(applied))
+	for _, change := range applied {
+		err := errs[change.Resource]
+		if change.Resource.Type == ResourceEC2 || change.Resource.Type == ResourceVolume {
+			err = failedIds[change.Resource.Id]
+		}
+		results = append(results, TagChangeResult{Change: change, Err: err})
+	}
+	return results
+}
+
+// 같은 태그 집합인지 비교할 키와 API 용 태그 목록
+func ec2TagSet(set map[string]string) (string, []ec2types.Tag) {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	tags := make([]ec2types.Tag, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+set[key])
+		tags = append(tags, ec2types.Tag{Key: aws.String(key), Value: aws.String(set[key])})
+	}
+	return strings.Join(pairs, "\x00"), tags
+}
+
+func applyRdsTagChange(ctx context.Context, client *rds.Client, change TagChange) error {
+	if len(change.Set) > 0 {
+		tags := make([]rdstypes.Tag, 0, len(change.Set))
+		for key, value := range change.Set {
+			tags = append(tags, rdstypes.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		if _, err := client.AddTagsToResource(ctx, &rds.AddTagsToResourceInput{ResourceName: aws.String(change.Resource.Arn), Tags: tags}); err != nil {
+			return err
+		}
+	}
+	if len(change.Remove) > 0 {
+		if _, err := client.RemoveTagsFromResource(ctx, &rds.RemoveTagsFromResourceInput{ResourceName: aws.String(change.Resource.Arn), TagKeys: change.Remove}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyElastiCacheTagChange(ctx context.Context, client *elasticache.Client, change TagChange) error {
+	if len(change.Set) > 0 {
+		tags := make([]elasticachetypes.Tag, 0, len(change.Set))
+		for key, value := range change.Set {
+			tags = append(tags, elasticachetypes.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		if _, err := client.AddTagsToResource(ctx, &elasticache.AddTagsToResourceInput{ResourceName: aws.String(change.Resource.Arn), Tags: tags}); err != nil {
+			return err
+		}
+	}
+	if len(change.Remove) > 0 {
+		if _, err := client.RemoveTagsFromResource(ctx, &elasticache.RemoveTagsFromResourceInput{ResourceName: aws.String(change.Resource.Arn), TagKeys: change.Remove}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 변경 미리보기 (+ 추가, ~ 변경, - 삭제)
+func PrintTagChange(cmd string, change TagChange) {
+	keys := make([]string, 0, len(change.Set))
+	for key := range change.Set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys)+len(change.Remove))
+	for _, key := range keys {
+		if current, ok := change.Resource.Tags[key]; ok {
+			lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", key, current, change.Set[key]))
+		} else {
+			lines = append(lines, fmt.Sprintf("+ %s=%s", key, change.Set[key]))
+		}
+	}
+	for _, key := range change.Remove {
+		lines = append(lines, fmt.Sprintf("- %s=%s", key, change.Resource.Tags[key]))
+	}
+	LogTagChange(cmd, change.Resource.Label(), lines)
+}
+
+func PrintTaggedResources(resources []*TaggedResource) {
+	rows := make([][]string, 0, len(resources))
+	for _, resource := range resources {
+		keys := make([]string, 0, len(resource.Tags))
+		for key := range resource.Tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, key+"="+resource.Tags[key])
+		}
+		rows = append(rows, []string{resource.Type, resource.Id, resource.Name, strings.Join(pairs, ", ")})
+	}
+	LogTable([]string{"TYPE", "ID", "NAME", "TAGS"}, rows)
+}
@@ -0,0 +1,331 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+type (
+	TransferOptions struct {
+		User      string
+		Recursive bool
+		Resume    bool
+	}
+
+	// 인스턴스별 전송 진행 상황 (여러 고루틴에서 갱신)
+	TransferProgress struct {
+		Target *Target
+
+		total int64
+		done  int64
+		files int64
+		state atomic.Value
+	}
+)
+
+const (
+	TransferStateConnecting = "connecting"
+	TransferStateRunning    = "transferring"
+	TransferStateDone       = "done"
+	TransferStateFailed     = "failed"
+
+	// 받는 중인 파일 (--resume 시 이어받기 기준)
+	PartialDownloadSuffix = ".mcl-part"
+
+	progressBarWidth = 30
+	resumeVerifySize = 64 * 1024
+)
+
+func NewTransferProgress(target *Target) *TransferProgress {
+	progress := &TransferProgress{Target: target}
+	progress.state.Store(TransferStateConnecting)
+	return progress
+}
+
+func (p *TransferProgress) SetState(state string) {
+	p.state.Store(state)
+}
+
+func (p *TransferProgress) Write(b []byte) (int, error) {
+	atomic.AddInt64(&p.done, int64(len(b)))
+	return len(b), nil
+}
+
+// bastion 경유 SFTP 로 로컬 파일/디렉토리를 타겟에 업로드
+func UploadToTarget(bastion *ssh.Client, target *Target, localPath, remotePath string, options TransferOptions, progress *TransferProgress) error {
+	client, closeClient, err := openSFTP(bastion, target, options.User)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() && !options.Recursive {
+		return fmt.Errorf("%s is a directory (use -R)", localPath)
+	}
+
+	remotePath = remoteTransferPath(remotePath)
+	// scp 와 같이 대상이 디렉토리이면 그 안에 복사
+	if remoteInfo, err := client.Stat(remotePath); err == nil && remoteInfo.IsDir() {
+		remotePath = path.Join(remotePath, filepath.Base(localPath))
+	}
+
+	if !info.IsDir() {
+		atomic.AddInt64(&progress.total, info.Size())
+		progress.SetState(TransferStateRunning)
+		return uploadFile(client, localPath, remotePath, info, progress)
+	}
+
+	var files []string
+	err = filepath.Walk(localPath, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.Mode().IsRegular() {
+			atomic.AddInt64(&progress.total, fi.Size())
+		}
+		files = append(files, p)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	progress.SetState(TransferStateRunning)
+	for _, p := range files {
+		fi, err := os.Lstat(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(localPath, p)
+		dst := path.Join(remotePath, filepath.ToSlash(rel))
+		switch {
+		case fi.IsDir():
+			if err := client.MkdirAll(dst); err != nil {
+				return fmt.Errorf("mkdir %s: %w", dst, err)
+			}
+		case fi.Mode().IsRegular():
+			if err := uploadFile(client, p, dst, fi, progress); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func uploadFile(client *sftp.Client, localPath, remotePath string, info os.FileInfo, progress *TransferProgress) error {
+	local, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	remote, err := client.OpenFile(remotePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", remotePath, err)
+	}
+	defer remote.Close()
+
+	if _, err := io.Copy(io.MultiWriter(remote, progress), local); err != nil {
+		return fmt.Errorf("upload %s: %w", remotePath, err)
+	}
+	atomic.AddInt64(&progress.files, 1)
+	return client.Chmod(remotePath, info.Mode().Perm())
+}
+
+// bastion 경유 SFTP 로 타겟의 파일/디렉토리를 로컬로 다운로드 (resume 시 .mcl-part 이어받기)
+func DownloadFromTarget(bastion *ssh.Client, target *Target, remotePath, localPath string, options TransferOptions, progress *TransferProgress) error {
+	client, closeClient, err := openSFTP(bastion, target, options.User)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	remotePath = remoteTransferPath(remotePath)
+	info, err := client.Stat(remotePath)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", remotePath, err)
+	}
+	if info.IsDir() && !options.Recursive {
+		return fmt.Errorf("%s is a directory (use -R)", remotePath)
+	}
+
+	if localInfo, err := os.Stat(localPath); err == nil && localInfo.IsDir() {
+		localPath = filepath.Join(localPath, path.Base(remotePath))
+	}
+
+	if !info.IsDir() {
+		atomic.AddInt64(&progress.total, info.Size())
+		progress.SetState(TransferStateRunning)
+		return downloadFile(client, remotePath, localPath, info, options.Resume, progress)
+	}
+
+	type remoteEntry struct {
+		path string
+		info os.FileInfo
+	}
+	var entries []remoteEntry
+	walker := client.Walk(remotePath)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return err
+		}
+		if walker.Stat().Mode().IsRegular() {
+			atomic.AddInt64(&progress.total, walker.Stat().Size())
+		}
+		entries = append(entries, remoteEntry{path: walker.Path(), info: walker.Stat()})
+	}
+
+	progress.SetState(TransferStateRunning)
+	for _, entry := range entries {
+		rel := strings.TrimPrefix(strings.TrimPrefix(entry.path, remotePath), "/")
+		dst := filepath.Join(localPath, filepath.FromSlash(rel))
+		switch {
+		case entry.info.IsDir():
+			if err := os.MkdirAll(dst, 0755); err != nil {
+				return err
+			}
+		case entry.info.Mode().IsRegular():
+			if err := downloadFile(client, entry.path, dst, entry.info, options.Resume, progress); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func downloadFile(client *sftp.Client, remotePath, localPath string, info os.FileInfo, resume bool, progress *TransferProgress) error {
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return err
+	}
+
+	remote, err := client.Open(remotePath)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", remotePath, err)
+	}
+	defer remote.Close()
+
+	// 완료 전까지 <name>.mcl-part 에 받고 끝나면 이름 변경
+	partPath := localPath + PartialDownloadSuffix
+	var offset int64
+	if resume {
+		offset = resumeOffset(remote, partPath, info.Size())
+	}
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if offset > 0 {
+		flag = os.O_WRONLY | os.O_APPEND
+	}
+	local, err := os.OpenFile(partPath, flag, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	atomic.AddInt64(&progress.done, offset)
+	if _, err := remote.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(local, progress), remote); err != nil {
+		return fmt.Errorf("download %s: %w", remotePath, err)
+	}
+	if err := local.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(partPath, localPath); err != nil {
+		return err
+	}
+	atomic.AddInt64(&progress.files, 1)
+	return nil
+}
+
+// 남아 있는 .mcl-part 의 끝부분이 원격 파일의 같은 위치와 일치할 때만 이어받기 (아니면 처음부터)
+func resumeOffset(remote *sftp.File, partPath string, remoteSize int64) int64 {
+	info, err := os.Stat(partPath)
+	if err != nil || info.Size() == 0 || info.Size() > remoteSize {
+		return 0
+	}
+	size := info.Size()
+	window := min(size, resumeVerifySize)
+
+	part, err := os.Open(partPath)
+	if err != nil {
+		return 0
+	}
+	defer part.Close()
+
+	localTail := make([]byte, window)
+	remoteTail := make([]byte, window)
+	if _, err := part.ReadAt(localTail, size-window); err != nil {
+		return 0
+	}
+	if _, err := remote.ReadAt(remoteTail, size-window); err != nil && err != io.EOF {
+		return 0
+	}
+	if !bytes.Equal(localTail, remoteTail) {
+		return 0
+	}
+	return size
+}
+
+func openSFTP(bastion *ssh.Client, target *Target, user string) (*sftp.Client, func(), error) {
+	targetClient, err := connectTarget(bastion, target, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := sftp.NewClient(targetClient)
+	if err != nil {
+		targetClient.Close()
+		return nil, nil, err
+	}
+	return client, func() {
+		client.Close()
+		targetClient.Close()
+	}, nil
+}
+
+// ~ 는 SFTP 기본 디렉토리(홈) 기준 상대 경로로 변환
+func remoteTransferPath(p string) string {
+	switch {
+	case p == "" || p == "~":
+		return "."
+	case strings.HasPrefix(p, "~/"):
+		return strings.TrimPrefix(p, "~/")
+	default:
+		return p
+	}
+}
+
+func PrintTransferProgress(cmd string, progress *TransferProgress) {
+	total := atomic.LoadInt64(&progress.total)
+	done := atomic.LoadInt64(&progress.done)
+	bar, percent := transferBar(done, total, progress.state.Load() == TransferStateDone)
+
+	LogTransferProgress(cmd, progress.Target.Name, progress.Target.Id, bar, percent,
+		FormatBytes(done), FormatBytes(total), atomic.LoadInt64(&progress.files), progress.state.Load().(string))
+}
+
+// 진행률 막대와 퍼센트 (전송 중 파일이 커지면 done 이 total 보다 클 수 있어 0~100 으로 제한)
+func transferBar(done, total int64, finished bool) (string, int) {
+	percent := 0
+	if total > 0 {
+		percent = int(done * 100 / total)
+	} else if finished {
+		percent = 100
+	}
+	percent = max(0, min(percent, 100))
+
+	filled := percent * progressBarWidth / 100
+	return strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled), percent
+}
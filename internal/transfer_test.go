@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransferBar(t *testing.T) {
+	tests := []struct {
+		name        string
+		done, total int64
+		finished    bool
+		wantPercent int
+		wantFilled  int
+	}{
+		{"start", 0, 100, false, 0, 0},
+		{"half", 50, 100, false, 50, progressBarWidth / 2},
+		{"complete", 100, 100, true, 100, progressBarWidth},
+		// 전송 중 파일이 커진 경우 (tail 중인 로그 등)
+		{"grown during transfer", 250, 100, false, 100, progressBarWidth},
+		{"empty file done", 0, 0, true, 100, progressBarWidth},
+		{"empty file pending", 0, 0, false, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar, percent := transferBar(tt.done, tt.total, tt.finished)
+			if percent != tt.wantPercent {
+				t.Errorf("percent = %d, want %d", percent, tt.wantPercent)
+			}
+			if len(bar) != progressBarWidth {
+				t.Errorf("bar width = %d, want %d", len(bar), progressBarWidth)
+			}
+			if filled := strings.Count(bar, "="); filled != tt.wantFilled {
+				t.Errorf("filled = %d, want %d", filled, tt.wantFilled)
+			}
+		})
+	}
+}
@@ -0,0 +1,596 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	dashboardPageMain  = "main"
+	dashboardPageModal = "modal"
+)
+
+type (
+	// 대시보드 테이블의 한 행
+	dashboardRow struct {
+		Columns []string
+		Detail  [][2]string
+		Ref     interface{}
+	}
+
+	// 대시보드 탭 (서비스별 finder를 감싼 로더)
+	dashboardTab struct {
+		Name    string
+		Headers []string
+		Keys    string
+		Load    func(ctx context.Context) ([]dashboardRow, error)
+
+		rows    []dashboardRow
+		loaded  time.Time
+		loading bool
+	}
+
+	// 변경 작업 결과 콜백 (감사 로그 기록용)
+	DashboardActionFunc func(command string, targets []string, params map[string]string, err error)
+
+	Dashboard struct {
+		ctx      context.Context
+		cfg      aws.Config
+		onAction DashboardActionFunc
+
+		app     *tview.Application
+		pages   *tview.Pages
+		tabBar  *tview.TextView
+		filter  *tview.InputField
+		table   *tview.Table
+		detail  *tview.TextView
+		status  *tview.TextView
+		tabs    []*dashboardTab
+		current int
+		visible []dashboardRow
+		modal   bool
+		bastion *Target
+	}
+)
+
+func NewDashboard(ctx context.Context, cfg aws.Config, onAction DashboardActionFunc) *Dashboard {
+	d := &Dashboard{
+		ctx:      ctx,
+		cfg:      cfg,
+		onAction: onAction,
+	}
+
+	d.tabs = []*dashboardTab{
+		{Name: "EC2", Headers: []string{"Name", "Id", "State", "Type", "Private IP", "Public IP", "Group"}, Keys: "s:SSM  b:bastion  v:volume", Load: d.loadEc2},
+		{Name: "RDS", Headers: []string{"Name", "Id", "Engine", "Status", "Endpoint"}, Load: d.loadRds},
+		{Name: "ElastiCache", Headers: []string{"Name", "Engine", "Status", "Endpoint"}, Load: d.loadElastiCache},
+		{Name: "S3", Headers: []string{"Bucket", "Region", "Created"}, Load: d.loadS3},
+		{Name: "CloudFront", Headers: []string{"Name", "Id", "Domain", "Status"}, Keys: "i:invalidation", Load: d.loadCloudFront},
+		{Name: "EKS", Headers: []string{"Name", "Version", "Status", "Endpoint"}, Load: d.loadEks},
+	}
+
+	return d
+}
+
+// 대시보드 실행 (refreshInterval 마다 현재 탭 갱신, 0이면 수동 갱신)
+func (d *Dashboard) Run(refreshInterval time.Duration) error {
+	d.app = tview.NewApplication()
+
+	d.tabBar = tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+
+	d.filter = tview.NewInputField().SetLabel(" / ").SetFieldWidth(0)
+	d.filter.SetChangedFunc(func(text string) {
+		d.render()
+	})
+	d.filter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			d.filter.SetText("")
+		}
+		d.app.SetFocus(d.table)
+	})
+
+	d.table = tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)
+	d.table.SetBorder(true)
+	d.table.SetSelectionChangedFunc(func(row, column int) {
+		d.renderDetail()
+	})
+
+	d.detail = tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	d.detail.SetBorder(true).SetTitle(" Detail ")
+
+	d.status = tview.NewTextView().SetDynamicColors(true)
+
+	body := tview.NewFlex().
+		AddItem(d.table, 0, 3, true).
+		AddItem(d.detail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(d.tabBar, 1, 0, false).
+		AddItem(d.filter, 1, 0, false).
+		AddItem(body, 0, 1, true).
+		AddItem(d.status, 2, 0, false)
+
+	d.pages = tview.NewPages().AddPage(dashboardPageMain, layout, true, true)
+	d.app.SetInputCapture(d.handleKey)
+
+	d.render()
+	d.refresh(d.current)
+
+	done := make(chan struct{})
+	defer close(done)
+	if refreshInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(refreshInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					d.app.QueueUpdate(func() {
+						d.refresh(d.current)
+					})
+				}
+			}
+		}()
+	}
+
+	return d.app.SetRoot(d.pages, true).EnableMouse(true).Run()
+}
+
+func (d *Dashboard) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if d.modal || d.app.GetFocus() == d.filter {
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyTab:
+		d.switchTab((d.current + 1) % len(d.tabs))
+		return nil
+	case tcell.KeyBacktab:
+		d.switchTab((d.current + len(d.tabs) - 1) % len(d.tabs))
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'q':
+		d.app.Stop()
+	case '/':
+		d.app.SetFocus(d.filter)
+	case 'r':
+		d.refresh(d.current)
+	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		if index := int(event.Rune() - '1'); index < len(d.tabs) {
+			d.switchTab(index)
+		}
+	case 's':
+		d.startSSMSession()
+	case 'b':
+		d.markBastion()
+	case 'v':
+		d.checkVolume()
+	case 'i':
+		d.createInvalidation()
+	default:
+		return event
+	}
+	return nil
+}
+
+func (d *Dashboard) switchTab(index int) {
+	d.current = index
+	d.table.Select(1, 0)
+	d.render()
+
+	tab := d.tabs[index]
+	if tab.loaded.IsZero() {
+		d.refresh(index)
+	}
+}
+
+// UI 고루틴에서 호출해야 함
+func (d *Dashboard) refresh(index int) {
+	tab := d.tabs[index]
+	if tab.loading {
+		return
+	}
+	tab.loading = true
+	d.setStatus("[yellow]%s 조회 중...", tab.Name)
+
+	go func() {
+		rows, err := tab.Load(d.ctx)
+		d.app.QueueUpdateDraw(func() {
+			tab.loading = false
+			if err != nil {
+				d.setStatus("[red]%s 조회 실패: %v", tab.Name, err)
+				return
+			}
+
+			sort.Slice(rows, func(i, j int) bool {
+				return strings.ToLower(rows[i].Columns[0]) < strings.ToLower(rows[j].Columns[0])
+			})
+			tab.rows = rows
+			tab.loaded = time.Now()
+
+			if index == d.current {
+				d.render()
+			}
+			d.setStatus("[green]%s %d개 조회 완료 (%s)", tab.Name, len(rows), tab.loaded.Format("15:04:05"))
+		})
+	}()
+}
+
+func (d *Dashboard) render() {
+	tab := d.tabs[d.current]
+
+	query := strings.ToLower(strings.TrimSpace(d.filter.GetText()))
+	visible := make([]dashboardRow, 0, len(tab.rows))
+	for _, row := range tab.rows {
+		if query == "" || strings.Contains(strings.ToLower(strings.Join(row.Columns, " ")), query) {
+			visible = append(visible, row)
+		}
+	}
+	d.visible = visible
+
+	selected, _ := d.table.GetSelection()
+	d.table.Clear()
+	for column, header := range tab.Headers {
+		d.table.SetCell(0, column, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+	for index, row := range visible {
+		for column, value := range row.Columns {
+			d.table.SetCell(index+1, column, tview.NewTableCell(tview.Escape(value)).SetExpansion(1))
+		}
+	}
+
+	if selected < 1 {
+		selected = 1
+	}
+	if selected > len(visible) {
+		selected = len(visible)
+	}
+	if selected > 0 {
+		d.table.Select(selected, 0)
+	}
+	d.table.SetTitle(fmt.Sprintf(" %s (%d/%d) ", tab.Name, len(visible), len(tab.rows)))
+
+	d.renderTabBar()
+	d.renderDetail()
+}
+
+func (d *Dashboard) renderTabBar() {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(" [::b]mcl[::-] [gray]%s[-]  ", d.cfg.Region))
+	for index, tab := range d.tabs {
+		if index == d.current {
+			builder.WriteString(fmt.Sprintf("[black:green] %d %s [-:-] ", index+1, tab.Name))
+		} else {
+			builder.WriteString(fmt.Sprintf("[gray] %d %s [-] ", index+1, tab.Name))
+		}
+	}
+	if d.bastion != nil {
+		builder.WriteString(fmt.Sprintf(" [yellow]bastion: %s[-]", tview.Escape(d.bastion.Name)))
+	}
+	d.tabBar.SetText(builder.String())
+}
+
+func (d *Dashboard) renderDetail() {
+	d.detail.Clear()
+
+	row := d.selectedRow()
+	if row == nil {
+		return
+	}
+
+	var builder strings.Builder
+	for _, field := range row.Detail {
+		builder.WriteString(fmt.Sprintf("[yellow]%s:[-] %s\n", field[0], tview.Escape(field[1])))
+	}
+	d.detail.SetText(builder.String())
+	d.detail.ScrollToBeginning()
+}
+
+func (d *Dashboard) setStatus(format string, args ...interface{}) {
+	keys := "q:quit  Tab/1-6:tab  /:filter  r:refresh"
+	if tab := d.tabs[d.current]; tab.Keys != "" {
+		keys = fmt.Sprintf("%s  %s", keys, tab.Keys)
+	}
+	d.status.SetText(fmt.Sprintf(" %s[-]\n [gray]%s[-]", fmt.Sprintf(format, args...), keys))
+}
+
+func (d *Dashboard) selectedRow() *dashboardRow {
+	row, _ := d.table.GetSelection()
+	if row < 1 || row > len(d.visible) {
+		return nil
+	}
+	return &d.visible[row-1]
+}
+
+func (d *Dashboard) selectedInstance() *Target {
+	row := d.selectedRow()
+	if row == nil {
+		return nil
+	}
+	target, _ := row.Ref.(*Target)
+	return target
+}
+
+// SSM, 볼륨 확인 등 실행 중인 인스턴스가 필요한 작업용
+func (d *Dashboard) selectedRunningInstance() *Target {
+	target := d.selectedInstance()
+	if target != nil && target.State != "running" {
+		d.setStatus("[yellow]실행 중인 인스턴스가 아닙니다: %s (%s) - %s", target.Name, target.Id, target.State)
+		return nil
+	}
+	return target
+}
+
+func (d *Dashboard) confirm(message string, onConfirm func()) {
+	modal := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"Yes", "No"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			d.pages.RemovePage(dashboardPageModal)
+			d.modal = false
+			d.app.SetFocus(d.table)
+			if buttonLabel == "Yes" {
+				onConfirm()
+			}
+		})
+
+	d.modal = true
+	d.pages.AddPage(dashboardPageModal, modal, true, true)
+}
+
+// 선택한 EC2 인스턴스에 SSM 세션 연결 (대시보드를 일시 중단)
+func (d *Dashboard) startSSMSession() {
+	target := d.selectedRunningInstance()
+	if target == nil {
+		return
+	}
+
+	if ok, missing := CheckSSMClientInstalled(); !ok {
+		d.setStatus("[red]SSM 클라이언트가 설치되어 있지 않습니다: %s", strings.Join(missing, ", "))
+		return
+	}
+
+	var err error
+	d.app.Suspend(func() {
+		LogInfo("SSM 세션을 시작합니다: %s (%s)", target.Name, target.Id)
+		err = StartSSMSession(d.ctx, target.Id, d.cfg.Region)
+	})
+	if err != nil {
+		d.setStatus("[red]SSM 세션 연결 실패: %v", err)
+		return
+	}
+	d.setStatus("[green]SSM 세션 종료: %s (%s)", target.Name, target.Id)
+}
+
+func (d *Dashboard) markBastion() {
+	target := d.selectedRunningInstance()
+	if target == nil {
+		return
+	}
+
+	d.bastion = target
+	d.renderTabBar()
+	d.setStatus("[green]bastion 지정: %s (%s)", target.Name, target.Id)
+}
+
+// 선택한 EC2 인스턴스의 루트 볼륨 사용량 확인 (bastion 경유)
+func (d *Dashboard) checkVolume() {
+	target := d.selectedRunningInstance()
+	if target == nil {
+		return
+	}
+	if d.bastion == nil {
+		d.setStatus("[yellow]먼저 bastion 인스턴스에서 'b'를 눌러 지정하세요")
+		return
+	}
+
+	bastion := d.bastion
+	d.setStatus("[yellow]볼륨 사용량 확인 중: %s (%s)", target.Name, target.Id)
+	go func() {
+		usage, err := func() (int, error) {
+			bastionClient, err := ConnectionBastion(bastion)
+			if err != nil {
+				return 0, err
+			}
+			return GetVolumeUsageWithTimeout(d.ctx, func(bastion *ssh.Client, target *Target) (int, error) {
+				return GetVolumeUsage(bastion, target)
+			}, 10*time.Second, bastionClient, target)
+		}()
+
+		d.app.QueueUpdateDraw(func() {
+			if err != nil {
+				d.setStatus("[red]볼륨 사용량 확인 실패: %s: %v", target.Id, err)
+				return
+			}
+			d.setStatus("[green]볼륨 사용량: %s (%s) %d%%", target.Name, target.Id, usage)
+		})
+	}()
+}
+
+// 선택한 CloudFront 배포에 /* 무효화 생성
+func (d *Dashboard) createInvalidation() {
+	row := d.selectedRow()
+	if row == nil {
+		return
+	}
+	target, ok := row.Ref.(*CloudFrontTarget)
+	if !ok {
+		return
+	}
+
+	d.confirm(fmt.Sprintf("%s (%s)에 /* 무효화를 생성하시겠습니까?", target.Name, target.Id), func() {
+		d.setStatus("[yellow]무효화 생성 중: %s", target.Id)
+		go func() {
+			err := CreateCloudFrontInvalidation(d.ctx, d.cfg, target.Id)
+			if d.onAction != nil {
+				d.onAction("cloudfront invalidation", []string{target.Id}, map[string]string{"paths": "/*"}, err)
+			}
+
+			d.app.QueueUpdateDraw(func() {
+				if err != nil {
+					d.setStatus("[red]무효화 생성 실패: %s: %v", target.Id, err)
+					return
+				}
+				d.setStatus("[green]무효화 생성 완료: %s (/*)", target.Id)
+			})
+		}()
+	})
+}
+
+func (d *Dashboard) loadEc2(ctx context.Context) ([]dashboardRow, error) {
+	table, err := FindInstanceByState(ctx, d.cfg, InstanceStates[:len(InstanceStates)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dashboardRow, 0, len(table))
+	for _, target := range table {
+		detail := [][2]string{
+			{"Name", target.Name},
+			{"Id", target.Id},
+			{"Private IP", target.PrivateIp},
+			{"Public IP", target.PublicIp},
+			{"Group", target.Group},
+			{"Key", target.KeyName},
+		}
+		rows = append(rows, dashboardRow{
+			Columns: []string{target.Name, target.Id, target.State, target.InstanceType, target.PrivateIp, target.PublicIp, target.Group},
+			Detail:  append(detail, target.Attributes()...),
+			Ref:     target,
+		})
+	}
+	return rows, nil
+}
+
+func (d *Dashboard) loadRds(ctx context.Context) ([]dashboardRow, error) {
+	table, err := FindRdsInstance(ctx, d.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dashboardRow, 0, len(table))
+	for _, target := range table {
+		rows = append(rows, dashboardRow{
+			Columns: []string{target.Name, target.Id, target.Engine, target.Status, target.Endpoint},
+			Detail: [][2]string{
+				{"Name", target.Name},
+				{"Id", target.Id},
+				{"Engine", target.Engine},
+				{"Status", target.Status},
+				{"Endpoint", target.Endpoint},
+			},
+			Ref: target,
+		})
+	}
+	return rows, nil
+}
+
+func (d *Dashboard) loadElastiCache(ctx context.Context) ([]dashboardRow, error) {
+	table, err := FindElastiCacheCluster(ctx, d.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dashboardRow, 0, len(table))
+	for _, target := range table {
+		endpoint := fmt.Sprintf("%s:%d", target.Endpoint, target.Port)
+		rows = append(rows, dashboardRow{
+			Columns: []string{target.Name, target.Engine, target.Status, endpoint},
+			Detail: [][2]string{
+				{"Name", target.Name},
+				{"Id", target.Id},
+				{"Engine", target.Engine},
+				{"Status", target.Status},
+				{"Endpoint", endpoint},
+			},
+			Ref: target,
+		})
+	}
+	return rows, nil
+}
+
+func (d *Dashboard) loadS3(ctx context.Context) ([]dashboardRow, error) {
+	buckets, err := FindS3Buckets(ctx, d.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dashboardRow, 0, len(buckets))
+	for _, bucket := range buckets {
+		created := bucket.CreationDate.Format("2006-01-02 15:04:05")
+		rows = append(rows, dashboardRow{
+			Columns: []string{bucket.Name, bucket.Region, created},
+			Detail: [][2]string{
+				{"Bucket", bucket.Name},
+				{"Region", bucket.Region},
+				{"Created", created},
+			},
+			Ref: bucket,
+		})
+	}
+	return rows, nil
+}
+
+func (d *Dashboard) loadCloudFront(ctx context.Context) ([]dashboardRow, error) {
+	table, err := FindCloudFrontDistribution(ctx, d.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dashboardRow, 0, len(table))
+	for _, target := range table {
+		rows = append(rows, dashboardRow{
+			Columns: []string{target.Name, target.Id, target.Domain, target.Status},
+			Detail: [][2]string{
+				{"Name", target.Name},
+				{"Id", target.Id},
+				{"Domain", target.Domain},
+				{"Aliases", strings.Join(target.Aliases, ", ")},
+				{"Status", target.Status},
+				{"Comment", target.Comment},
+			},
+			Ref: target,
+		})
+	}
+	return rows, nil
+}
+
+func (d *Dashboard) loadEks(ctx context.Context) ([]dashboardRow, error) {
+	clusters, err := ListEksClusters(ctx, d.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dashboardRow, 0, len(clusters))
+	for index := range clusters {
+		cluster := &clusters[index]
+		rows = append(rows, dashboardRow{
+			Columns: []string{cluster.Name, cluster.Version, cluster.Status, cluster.Endpoint},
+			Detail: [][2]string{
+				{"Name", cluster.Name},
+				{"Arn", cluster.Arn},
+				{"Version", cluster.Version},
+				{"Status", cluster.Status},
+				{"Endpoint", cluster.Endpoint},
+				{"Role", cluster.RoleArn},
+			},
+			Ref: cluster,
+		})
+	}
+	return rows, nil
+}
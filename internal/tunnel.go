@@ -0,0 +1,248 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"golang.org/x/crypto/ssh"
+)
+
+type (
+	// bastion 경유 로컬 포트 포워딩
+	Tunnel struct {
+		Name string
+		Port Port
+
+		bastion  *ssh.Client
+		listener net.Listener
+
+		active   int64
+		total    int64
+		bytesIn  int64
+		bytesOut int64
+	}
+
+	// 전송 중에도 전송량이 갱신되도록 쓰기마다 누적
+	countingWriter struct {
+		w io.Writer
+		n *int64
+	}
+
+	TunnelStats struct {
+		Active   int64
+		Total    int64
+		BytesIn  int64
+		BytesOut int64
+	}
+)
+
+const (
+	tunnelLocalHost = "127.0.0.1"
+
+	tunnelKindRds         = "RDS"
+	tunnelKindElastiCache = "ElastiCache"
+	tunnelKindEc2         = "EC2"
+	tunnelKindCustom      = "직접 입력 (host:port)"
+)
+
+// -L 값 파싱: [localPort:]host:port (IPv6 는 [::1]:5432 처럼 대괄호)
+func ParseForward(value string) (*Port, error) {
+	value = strings.TrimSpace(value)
+	rest, local := value, ""
+	if i := strings.Index(value, ":"); i > 0 {
+		if _, err := strconv.Atoi(value[:i]); err == nil {
+			if _, _, err := net.SplitHostPort(value[i+1:]); err == nil {
+				rest, local = value[i+1:], value[:i]
+			}
+		}
+	}
+
+	host, port, err := net.SplitHostPort(rest)
+	if err != nil || host == "" {
+		return nil, fmt.Errorf("invalid forward %q (expected [localPort:]host:port, IPv6 as [addr]:port)", value)
+	}
+	if _, err := strconv.Atoi(port); err != nil {
+		return nil, fmt.Errorf("invalid forward %q: port must be a number", value)
+	}
+	return &Port{Remote: net.JoinHostPort(host, port), Local: local}, nil
+}
+
+// 로컬 포트를 열고 bastion 경유 포워딩 준비 (Local 이 비어 있으면 원격과 같은 포트, 사용 중이면 임의 포트)
+func OpenTunnel(bastion *ssh.Client, name string, port Port) (*Tunnel, error) {
+	var listener net.Listener
+	var err error
+	if port.Local != "" {
+		listener, err = net.Listen("tcp", net.JoinHostPort(tunnelLocalHost, port.Local))
+	} else {
+		_, remotePort, _ := net.SplitHostPort(port.Remote)
+		if listener, err = net.Listen("tcp", net.JoinHostPort(tunnelLocalHost, remotePort)); err != nil {
+			listener, err = net.Listen("tcp", net.JoinHostPort(tunnelLocalHost, "0"))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	port.Local = strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	return &Tunnel{Name: name, Port: port, bastion: bastion, listener: listener}, nil
+}
+
+// 연결을 받아 원격으로 전달 (ctx 가 끝나거나 Close 될 때까지)
+func (t *Tunnel) Serve(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		t.listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.forward(local)
+		}()
+	}
+}
+
+func (t *Tunnel) forward(local net.Conn) {
+	defer local.Close()
+
+	remote, err := t.bastion.Dial("tcp", t.Port.Remote)
+	if err != nil {
+		PrintError(fmt.Errorf("tunnel %s: cannot reach %s: %v", t.Name, t.Port.Remote, err))
+		return
+	}
+	defer remote.Close()
+
+	atomic.AddInt64(&t.active, 1)
+	atomic.AddInt64(&t.total, 1)
+	defer atomic.AddInt64(&t.active, -1)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(&countingWriter{w: remote, n: &t.bytesOut}, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(&countingWriter{w: local, n: &t.bytesIn}, remote)
+		done <- struct{}{}
+	}()
+
+	// 한쪽이 끊기면 양쪽 모두 종료
+	<-done
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	atomic.AddInt64(c.n, int64(n))
+	return n, err
+}
+
+func (t *Tunnel) Stats() TunnelStats {
+	return TunnelStats{
+		Active:   atomic.LoadInt64(&t.active),
+		Total:    atomic.LoadInt64(&t.total),
+		BytesIn:  atomic.LoadInt64(&t.bytesIn),
+		BytesOut: atomic.LoadInt64(&t.bytesOut),
+	}
+}
+
+func (t *Tunnel) LocalAddress() string {
+	return net.JoinHostPort(tunnelLocalHost, t.Port.Local)
+}
+
+func (t *Tunnel) Close() error {
+	return t.listener.Close()
+}
+
+// 터널 목적지 선택 (RDS, ElastiCache, EC2, 직접 입력)
+func AskTunnelDestination(ctx context.Context, cfg aws.Config) (string, *Port, error) {
+	kind, err := AskMenu("터널 대상 종류를 선택하세요:", []string{tunnelKindRds, tunnelKindElastiCache, tunnelKindEc2, tunnelKindCustom})
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch kind {
+	case tunnelKindRds:
+		target, err := AskRdsTarget(ctx, cfg)
+		if err != nil {
+			return "", nil, err
+		}
+		if target.Endpoint == "" {
+			return "", nil, fmt.Errorf("rds instance %s has no endpoint (status: %s)", target.Id, target.Status)
+		}
+		return target.Name, &Port{Remote: net.JoinHostPort(target.Endpoint, strconv.Itoa(int(target.Port)))}, nil
+	case tunnelKindElastiCache:
+		target, err := AskElastiCacheTarget(ctx, cfg)
+		if err != nil {
+			return "", nil, err
+		}
+		if target.Endpoint == "" {
+			return "", nil, fmt.Errorf("elasticache cluster %s has no endpoint (status: %s)", target.Id, target.Status)
+		}
+		return target.Name, &Port{Remote: net.JoinHostPort(target.Endpoint, strconv.Itoa(int(target.Port)))}, nil
+	case tunnelKindEc2:
+		target, err := AskTarget(ctx, cfg)
+		if err != nil {
+			return "", nil, err
+		}
+		remotePort, err := askPortNumber("원격 포트를 입력하세요:", "22")
+		if err != nil {
+			return "", nil, err
+		}
+		return target.Name, &Port{Remote: net.JoinHostPort(target.PrivateIp, remotePort)}, nil
+	default:
+		var address string
+		prompt := &survey.Input{Message: "목적지 주소를 입력하세요 (host:port):"}
+		if err := survey.AskOne(prompt, &address, survey.WithValidator(func(answer interface{}) error {
+			_, err := ParseForward(answer.(string))
+			return err
+		})); err != nil {
+			return "", nil, err
+		}
+		port, err := ParseForward(address)
+		if err != nil {
+			return "", nil, err
+		}
+		return port.Remote, port, nil
+	}
+}
+
+func askPortNumber(message, defaultPort string) (string, error) {
+	var port string
+	prompt := &survey.Input{Message: message, Default: defaultPort}
+	if err := survey.AskOne(prompt, &port, survey.WithValidator(func(answer interface{}) error {
+		n, err := strconv.Atoi(answer.(string))
+		if err != nil || n <= 0 || n > 65535 {
+			return fmt.Errorf("invalid port number")
+		}
+		return nil
+	})); err != nil {
+		return "", err
+	}
+	return port, nil
+}
+
+func PrintTunnel(cmd string, tunnel *Tunnel) {
+	stats := tunnel.Stats()
+	LogTunnel(cmd, tunnel.Name, tunnel.LocalAddress(), tunnel.Port.Remote, stats.Active, stats.Total, FormatBytes(stats.BytesIn), FormatBytes(stats.BytesOut))
+}
@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	ReleaseAsset struct {
+		Name        string `json:"name"`
+		DownloadURL string `json:"browser_download_url"`
+	}
+
+	Release struct {
+		TagName string         `json:"tag_name"`
+		HTMLURL string         `json:"html_url"`
+		Assets  []ReleaseAsset `json:"assets"`
+	}
+)
+
+const (
+	DefaultReleaseURL = "https://api.github.com/repos/masuldev/mcl/releases/latest"
+
+	// .goreleaser.yaml 의 checksum.name_template
+	checksumsFileName = "checksums.txt"
+	binaryName        = "mcl"
+	updateTimeout     = 2 * time.Minute
+	maxArchiveSize    = 200 << 20
+)
+
+var updateClient = &http.Client{Timeout: updateTimeout}
+
+// 최신 릴리즈 정보 조회 (GitHub releases API 형식)
+func FetchLatestRelease(ctx context.Context, releaseURL string) (*Release, error) {
+	body, err := httpGet(ctx, releaseURL, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("invalid release response from %s: %w", releaseURL, err)
+	}
+	if release.TagName == "" {
+		return nil, fmt.Errorf("release response from %s has no tag_name", releaseURL)
+	}
+	return &release, nil
+}
+
+// 릴리즈 버전 (v 접두사 제거)
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+func (r *Release) findAsset(name string) *ReleaseAsset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// 현재 OS/아키텍처에 맞는 아카이브 이름 (.goreleaser.yaml 의 archives.name_template)
+func ReleaseArchiveName(version string) string {
+	return fmt.Sprintf("%s_%s_%s_%s.tar.gz", binaryName, version, runtime.GOOS, runtime.GOARCH)
+}
+
+// latest가 current보다 새로운 버전인지 비교 (개발 빌드는 항상 오래된 것으로 간주)
+func IsNewerVersion(current, latest string) bool {
+	currentParts, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	latestParts, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < len(latestParts); i++ {
+		if latestParts[i] != currentParts[i] {
+			return latestParts[i] > currentParts[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// 릴리즈 아카이브를 내려받아 checksums.txt 로 검증 후 바이너리 추출
+func DownloadRelease(ctx context.Context, release *Release) ([]byte, error) {
+	archiveName := ReleaseArchiveName(release.Version())
+
+	archiveAsset := release.findAsset(archiveName)
+	if archiveAsset == nil {
+		return nil, fmt.Errorf("release %s has no archive for %s/%s (%s)", release.TagName, runtime.GOOS, runtime.GOARCH, archiveName)
+	}
+	checksumsAsset := release.findAsset(checksumsFileName)
+	if checksumsAsset == nil {
+		return nil, fmt.Errorf("release %s has no %s", release.TagName, checksumsFileName)
+	}
+
+	checksums, err := httpGet(ctx, checksumsAsset.DownloadURL, "")
+	if err != nil {
+		return nil, err
+	}
+	expected, err := findChecksum(checksums, archiveName)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := httpGet(ctx, archiveAsset.DownloadURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(archive)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archiveName, expected, actual)
+	}
+
+	return extractBinary(archive)
+}
+
+// checksums.txt 형식: "<sha256>  <file name>"
+func findChecksum(checksums []byte, fileName string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == fileName {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no checksum for %s in %s", fileName, checksumsFileName)
+}
+
+func extractBinary(archive []byte) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == binaryName {
+			return io.ReadAll(io.LimitReader(tarReader, maxArchiveSize))
+		}
+	}
+	return nil, fmt.Errorf("%s binary not found in archive", binaryName)
+}
+
+// 실행 중인 바이너리를 원자적으로 교체 (같은 디렉토리에 임시 파일 작성 후 rename)
+func ReplaceExecutable(binary []byte) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(executable)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(executable), ".mcl-update-*")
+	if err != nil {
+		return "", fmt.Errorf("cannot write to %s: %w", filepath.Dir(executable), err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0111); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmpPath, executable); err != nil {
+		return "", err
+	}
+	return executable, nil
+}
+
+func httpGet(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := updateClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxArchiveSize))
+}
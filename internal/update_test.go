@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// GitHub releases API 와 자산 다운로드를 흉내 내는 로컬 서버
+type releaseStandIn struct {
+	server *httptest.Server
+	files  map[string][]byte
+}
+
+func newReleaseStandIn(t *testing.T, version string, files map[string][]byte) *releaseStandIn {
+	t.Helper()
+	standIn := &releaseStandIn{files: files}
+	standIn.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/releases/latest" {
+			release := Release{TagName: "v" + version, HTMLURL: standIn.server.URL + "/releases/v" + version}
+			for name := range standIn.files {
+				release.Assets = append(release.Assets, ReleaseAsset{Name: name, DownloadURL: standIn.server.URL + "/download/" + name})
+			}
+			json.NewEncoder(w).Encode(release)
+			return
+		}
+		content, ok := standIn.files[strings.TrimPrefix(r.URL.Path, "/download/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(content)
+	}))
+	t.Cleanup(standIn.server.Close)
+	return standIn
+}
+
+func (s *releaseStandIn) releaseURL() string {
+	return s.server.URL + "/releases/latest"
+}
+
+func tarGz(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range entries {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tarWriter.Write(content)
+	}
+	tarWriter.Close()
+	gzipWriter.Close()
+	return buf.Bytes()
+}
+
+func checksumLine(name string, content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)
+}
+
+func TestDownloadRelease(t *testing.T) {
+	const version = "9.9.9"
+	archiveName := ReleaseArchiveName(version)
+	otherArch := "arm64"
+	if runtime.GOARCH == "arm64" {
+		otherArch = "amd64"
+	}
+	decoyName := fmt.Sprintf("%s_%s_%s_%s.tar.gz", binaryName, version, runtime.GOOS, otherArch)
+
+	archive := tarGz(t, map[string][]byte{"README.md": []byte("readme"), binaryName: []byte("new mcl binary")})
+	decoy := tarGz(t, map[string][]byte{binaryName: []byte("wrong platform")})
+
+	tests := []struct {
+		name      string
+		checksums string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "selects platform archive and extracts binary",
+			checksums: checksumLine(decoyName, decoy) + checksumLine(archiveName, archive),
+			want:      "new mcl binary",
+		},
+		{
+			name:      "rejects checksum mismatch",
+			checksums: checksumLine(decoyName, decoy) + checksumLine(archiveName, []byte("tampered")),
+			wantErr:   "checksum mismatch",
+		},
+		{
+			name:      "rejects missing checksum",
+			checksums: checksumLine(decoyName, decoy),
+			wantErr:   "no checksum for " + archiveName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := newReleaseStandIn(t, version, map[string][]byte{
+				archiveName:       archive,
+				decoyName:         decoy,
+				checksumsFileName: []byte(tt.checksums),
+			})
+
+			release, err := FetchLatestRelease(context.Background(), standIn.releaseURL())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if release.Version() != version {
+				t.Fatalf("version = %s, want %s", release.Version(), version)
+			}
+
+			binary, err := DownloadRelease(context.Background(), release)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(binary) != tt.want {
+				t.Fatalf("binary = %q, want %q", binary, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadReleaseWithoutPlatformArchive(t *testing.T) {
+	standIn := newReleaseStandIn(t, "9.9.9", map[string][]byte{
+		"mcl_9.9.9_plan9_mips.tar.gz": tarGz(t, map[string][]byte{binaryName: []byte("x")}),
+		checksumsFileName:             []byte(""),
+	})
+
+	release, err := FetchLatestRelease(context.Background(), standIn.releaseURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DownloadRelease(context.Background(), release); err == nil || !strings.Contains(err.Error(), "has no archive") {
+		t.Fatalf("err = %v, want missing archive error", err)
+	}
+}
+
+func TestIsNewerVersion(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"1.2.3", "1.2.4", true},
+		{"v1.2.3", "1.10.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"2.0.0", "1.9.9", false},
+		{"", "1.0.0", true},
+		{"1.0.0", "garbage", false},
+	}
+	for _, tt := range tests {
+		if got := IsNewerVersion(tt.current, tt.latest); got != tt.want {
+			t.Errorf("IsNewerVersion(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package internal
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/user"
+	"strings"
 	"sync"
+	"text/tabwriter"
 	"time"
 
 	"github.com/fatih/color"
@@ -73,6 +76,117 @@ func LogEC2Instance(cmd, region, name, id, publicIp, privateIp string) {
 		color.YellowString(id), color.BlueString(publicIp), color.BlueString(privateIp))
 }
 
+// 인스턴스 그룹 로그 출력
+func LogInstanceGroup(cmd, groupTag, group string, count int) {
+	fmt.Printf("%s: %s: %s, instances: %s\n",
+		color.CyanString(cmd), groupTag, color.YellowString(group), color.GreenString("%d", count))
+}
+
+// 상세 속성 로그 출력 (값이 없는 항목은 생략)
+func LogAttributes(attributes [][2]string) {
+	for _, attribute := range attributes {
+		if attribute[1] == "" {
+			continue
+		}
+		fmt.Printf("  %s: %s\n", color.HiBlackString(attribute[0]), attribute[1])
+	}
+}
+
+// 상세 출력의 구역 제목
+func LogSection(title string) {
+	fmt.Printf("\n%s\n", color.New(color.Bold, color.FgCyan).Sprint(title))
+}
+
+// EC2 인스턴스 액션 결과 로그 출력
+func LogInstanceAction(cmd, action, id, name, previousState, currentState, status, duration, message string) {
+	statusString := color.GreenString(status)
+	switch status {
+	case "skipped":
+		statusString = color.YellowString(status)
+	case "failed":
+		statusString = color.RedString(status)
+	}
+
+	line := fmt.Sprintf("%s: action: %s, id: %s, name: %s, state: %s -> %s, result: %s, duration: %s",
+		color.CyanString(cmd), color.MagentaString(action), color.YellowString(id), color.YellowString(name),
+		color.BlueString(previousState), color.BlueString(currentState), statusString, duration)
+	if message != "" {
+		line += fmt.Sprintf(", message: %s", message)
+	}
+	fmt.Println(line)
+}
+
+// 배치 작업 인스턴스별 결과 로그 출력 (여러 줄 출력은 들여쓰기)
+func LogBatchResult(cmd, id, name, status, duration, message string) {
+	statusString := color.GreenString(status)
+	if status == "failed" {
+		statusString = color.RedString(status)
+	}
+
+	fmt.Printf("%s: id: %s, name: %s, result: %s, duration: %s\n",
+		color.CyanString(cmd), color.YellowString(id), color.YellowString(name), statusString, duration)
+	message = strings.TrimRight(message, "\n")
+	if message != "" {
+		fmt.Printf("    %s\n", strings.ReplaceAll(message, "\n", "\n    "))
+	}
+}
+
+// 배치 작업 요약 로그 출력
+func LogBatchSummary(cmd, operation string, succeeded, failed int) {
+	line := fmt.Sprintf("%s: %s: %d succeeded, %d failed", cmd, operation, succeeded, failed)
+	if failed > 0 {
+		fmt.Println(color.RedString(line))
+		return
+	}
+	fmt.Println(color.GreenString(line))
+}
+
+// 터널 상태 로그 출력
+func LogTunnel(cmd, name, local, remote string, active, total int64, bytesIn, bytesOut string) {
+	fmt.Printf("%s: %s: %s -> %s, connections: %s (total %d), in: %s, out: %s\n",
+		color.CyanString(cmd), color.YellowString(name), color.GreenString(local), color.BlueString(remote),
+		color.MagentaString("%d", active), total, bytesIn, bytesOut)
+}
+
+// 파일 전송 진행률 로그 출력
+func LogTransferProgress(cmd, name, id, bar string, percent int, done, total string, files int64, state string) {
+	stateString := color.YellowString(state)
+	switch state {
+	case "done":
+		stateString = color.GreenString(state)
+	case "failed":
+		stateString = color.RedString(state)
+	}
+
+	fmt.Printf("%s: %s (%s) [%s] %3d%% %s / %s, files: %d, %s\n",
+		color.CyanString(cmd), color.YellowString(name), id, color.GreenString(bar), percent, done, total, files, stateString)
+}
+
+// 태그 변경 미리보기 로그 출력
+func LogTagChange(cmd, resource string, lines []string) {
+	fmt.Printf("%s: %s\n", color.CyanString(cmd), color.YellowString(resource))
+	for _, line := range lines {
+		switch line[0] {
+		case '+':
+			fmt.Printf("  %s\n", color.GreenString(line))
+		case '-':
+			fmt.Printf("  %s\n", color.RedString(line))
+		default:
+			fmt.Printf("  %s\n", color.YellowString(line))
+		}
+	}
+}
+
+// 헤더와 행으로 정렬된 표 출력
+func LogTable(headers []string, rows [][]string) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	writer.Flush()
+}
+
 // 볼륨 사용량 로그 출력
 func LogVolumeUsage(cmd, instanceId, instanceName, instanceIp string, usage int) {
 	fmt.Printf("%s: instance id: %s, instance name: %s, instance ip: %s, usage: %s\n",
@@ -115,6 +229,34 @@ func LogS3Object(cmd, region, bucketName, objectKey, size, lastModified string)
 		color.CyanString(objectKey), color.GreenString(size), color.MagentaString(lastModified))
 }
 
+// 감사 로그 출력
+func LogAuditEntry(cmd, timestamp, identity, profile, region, command, targets, params, outcome string) {
+	fmt.Printf("%s: time: %s, identity: %s, profile: %s, region: %s, command: %s, targets: %s, params: %s, outcome: %s\n",
+		color.CyanString(cmd), color.YellowString(timestamp), color.BlueString(identity), color.YellowString(profile),
+		color.YellowString(region), color.CyanString(command), color.YellowString(targets), color.MagentaString(params),
+		color.GreenString(outcome))
+}
+
+// doctor 점검 결과 출력
+func LogDoctorCheck(status, name, detail, fix string) {
+	var badge string
+	switch status {
+	case "PASS":
+		badge = color.GreenString("[%s]", status)
+	case "WARN":
+		badge = color.YellowString("[%s]", status)
+	case "FAIL":
+		badge = color.RedString("[%s]", status)
+	default:
+		badge = color.HiBlackString("[%s]", status)
+	}
+
+	fmt.Printf("%s %s: %s\n", badge, color.CyanString(name), detail)
+	if fix != "" {
+		fmt.Printf("       fix: %s\n", color.MagentaString(fix))
+	}
+}
+
 func GetVolumeUsageWithTimeout(ctx context.Context, f func(bastion *ssh.Client, target *Target) (int, error), timeout time.Duration, bastion *ssh.Client, target *Target) (int, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -14,16 +14,19 @@ func main() {
 	// 프로그램 종료 시 SSH 연결 풀 정리
 	defer internal.CleanupSSHConnections()
 
-	// 새로운 AWS 인증 시스템 초기화
-	auth, err := internal.NewAwsAuth()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "AWS 인증 초기화 실패: %v\n", err)
-		os.Exit(1)
-	}
+	if cmd.RequiresCredential(os.Args[1:]) {
+		// 새로운 AWS 인증 시스템 초기화
+		auth, err := internal.NewAwsAuth()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "AWS 인증 초기화 실패: %v\n", err)
+			os.Exit(1)
+		}
 
-	// 전역 AWS Config 설정
-	cmd.SetGlobalAwsConfig(auth.GetConfig())
-	cmd.SetGlobalRegion(auth.GetRegion())
+		// 전역 AWS Config 설정
+		cmd.SetGlobalAwsConfig(auth.GetConfig())
+		cmd.SetGlobalRegion(auth.GetRegion())
+		cmd.SetGlobalProfile(auth.Profile)
+	}
 
 	cmd.Execute(mclVersion)
 }